@@ -37,7 +37,7 @@ func main() {
 	p := parser.NewParser(sugar, l)
 
 	query := p.Parse()
-	if len(query.Statements) > 0 {
+	if len(query.Batches) > 0 {
 		fmt.Fprintln(os.Stdout, query.TokenLiteral())
 	}
 	if len(p.Errors()) > 0 {
@@ -2,10 +2,13 @@ module SequelGo
 
 go 1.22.1
 
+require (
+	github.com/spf13/cobra v1.8.1
+	go.uber.org/zap v1.27.0
+)
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.8.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	go.uber.org/zap v1.27.0 // indirect
 )
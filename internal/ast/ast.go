@@ -26,9 +26,28 @@ type Expression interface {
 	expressionNode()
 }
 
+type QueryExpression interface {
+	Node
+	queryExpressionNode()
+}
+
+type MergeAction interface {
+	Node
+	mergeActionNode()
+}
+
 type Query struct {
 	Span
-	Statements       []Statement
+	Batches []Batch
+}
+
+// the statements up to a GO separator or the end of the input
+type Batch struct {
+	Span
+	Statements []Statement
+	GoKeyword  *Keyword
+	// GO 5 runs the batch five times
+	Count *ExprNumberLiteral
 }
 
 type Comment struct {
@@ -62,17 +81,35 @@ func NewComment(token lexer.Token) Comment {
 }
 
 func (q *Query) SetSpan(span Span)   { q.Span = span }
+func (b *Batch) SetSpan(span Span)   { b.Span = span }
 func (c *Comment) SetSpan(span Span) { c.Span = span }
 
 func (q Query) GetSpan() Span   { return q.Span }
+func (b Batch) GetSpan() Span   { return b.Span }
 func (c Comment) GetSpan() Span { return c.Span }
 
 func (q *Query) TokenLiteral() string {
 	str := strings.Builder{}
 
-	for _, s := range q.Statements {
+	for _, b := range q.Batches {
+		str.WriteString(b.TokenLiteral())
+	}
+
+	return str.String()
+}
+func (b *Batch) TokenLiteral() string {
+	str := strings.Builder{}
+
+	for _, s := range b.Statements {
 		str.WriteString(s.TokenLiteral())
 	}
+	if b.GoKeyword != nil {
+		str.WriteString(fmt.Sprintf("\n%s", b.GoKeyword.TokenLiteral()))
+		if b.Count != nil {
+			str.WriteString(fmt.Sprintf(" %s", b.Count.TokenLiteral()))
+		}
+		str.WriteString("\n")
+	}
 
 	return str.String()
 }
@@ -11,7 +11,8 @@ var DataTypeTokenTypes = []lexer.TokenType{
 	lexer.TSmallint, lexer.TBit, lexer.TFloat,
 	lexer.TReal, lexer.TDate, lexer.TDatetime,
 	lexer.TTime, lexer.TDecimal, lexer.TNumeric,
-	lexer.TVarchar,
+	lexer.TVarchar, lexer.TChar, lexer.TNchar,
+	lexer.TNvarchar, lexer.TVarbinary,
 }
 
 type DataType struct {
@@ -20,6 +21,8 @@ type DataType struct {
 	FloatPrecision     *uint32
 	DecimalNumericSize *NumericSize
 	VarcharLength      *uint32
+	// (MAX) on the variable length types
+	MaxLength          bool
 }
 
 type NumericSize struct {
@@ -93,14 +96,38 @@ func (dt DataType) TokenLiteral() string {
 		break
 	case DTVarchar:
 		str.WriteString("VARCHAR")
-		if dt.VarcharLength != nil {
-			str.WriteString(fmt.Sprintf("(%d)", *dt.VarcharLength))
-		}
+		str.WriteString(dt.lengthToString())
+		break
+	case DTChar:
+		str.WriteString("CHAR")
+		str.WriteString(dt.lengthToString())
+		break
+	case DTNchar:
+		str.WriteString("NCHAR")
+		str.WriteString(dt.lengthToString())
+		break
+	case DTNvarchar:
+		str.WriteString("NVARCHAR")
+		str.WriteString(dt.lengthToString())
+		break
+	case DTVarbinary:
+		str.WriteString("VARBINARY")
+		str.WriteString(dt.lengthToString())
 		break
 	}
 	return str.String()
 }
 
+func (dt DataType) lengthToString() string {
+	if dt.MaxLength {
+		return "(MAX)"
+	}
+	if dt.VarcharLength != nil {
+		return fmt.Sprintf("(%d)", *dt.VarcharLength)
+	}
+	return ""
+}
+
 func (dt DataType) GetSpan() Span    { return dt.Span }
 func (ns NumericSize) GetSpan() Span { return ns.Span }
 
@@ -123,4 +150,8 @@ const (
 	DTDecimal
 	DTNumeric
 	DTVarchar
+	DTChar
+	DTNchar
+	DTNvarchar
+	DTVarbinary
 )
@@ -0,0 +1,607 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+func keywordsToString(keywords []Keyword) string {
+	var items []string
+	for _, k := range keywords {
+		items = append(items, k.TokenLiteral())
+	}
+	return strings.Join(items, " ")
+}
+
+func tableElementsToString(elements []TableElement) string {
+	var items []string
+	for _, e := range elements {
+		items = append(items, e.TokenLiteral())
+	}
+	return strings.Join(items, ", ")
+}
+
+func routineParametersToString(parameters []RoutineParameter) string {
+	var items []string
+	for _, p := range parameters {
+		items = append(items, p.TokenLiteral())
+	}
+	return strings.Join(items, ", ")
+}
+
+func routineOptionsToString(withKeyword *Keyword, options []RoutineOption) string {
+	if withKeyword == nil {
+		return ""
+	}
+
+	var items []string
+	for _, o := range options {
+		items = append(items, o.TokenLiteral())
+	}
+	return fmt.Sprintf(" %s %s", withKeyword.TokenLiteral(), strings.Join(items, ", "))
+}
+
+// column definitions and table level constraints
+type TableElement interface {
+	Node
+	tableElementNode()
+}
+
+type AlterTableAction interface {
+	Node
+	alterTableActionNode()
+}
+
+type CreateTableStatement struct {
+	Span
+	CreateKeyword Keyword
+	TableKeyword  Keyword
+	Name          Expression
+	Definitions   []TableElement
+}
+
+type AlterTableStatement struct {
+	Span
+	AlterKeyword Keyword
+	TableKeyword Keyword
+	Name         Expression
+	Action       AlterTableAction
+}
+
+type DropTableStatement struct {
+	Span
+	DropKeyword      Keyword
+	TableKeyword     Keyword
+	IfExistsKeywords []Keyword
+	Names            []Expression
+}
+
+type ColumnDefinition struct {
+	Span
+	Name     Expression
+	DataType *DataType
+	// computed columns have AS expr [PERSISTED] instead of a data type
+	AsKeyword        *Keyword
+	ComputedValue    Expression
+	PersistedKeyword *Keyword
+	Identity         *IdentityProperty
+	NullKeywords     []Keyword
+	Constraints      []TableConstraint
+}
+
+type IdentityProperty struct {
+	Span
+	IdentityKeyword Keyword
+	Seed            Expression
+	Increment       Expression
+}
+
+type TableConstraint struct {
+	Span
+	ConstraintKeyword *Keyword
+	Name              Expression
+	Type              ConstraintType
+	// PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK or DEFAULT
+	TypeKeywords      []Keyword
+	ClusteredKeyword  *Keyword
+	Columns           []OrderByArg
+	ReferencesKeyword *Keyword
+	ReferencedTable   Expression
+	ReferencedColumns *ExprExpressionList
+	OnDeleteKeywords  []Keyword
+	OnUpdateKeywords  []Keyword
+	// CHECK condition or DEFAULT value
+	Expression Expression
+	// DEFAULT value FOR column when added with ALTER TABLE
+	ForKeyword *Keyword
+	ForColumn  Expression
+}
+
+type ConstraintType uint8
+
+const (
+	CTPrimaryKey ConstraintType = iota
+	CTUnique
+	CTForeignKey
+	CTCheck
+	CTDefault
+)
+
+type AlterTableAddAction struct {
+	Span
+	AddKeyword  Keyword
+	Definitions []TableElement
+}
+
+type AlterTableDropAction struct {
+	Span
+	DropKeyword Keyword
+	// COLUMN or CONSTRAINT
+	TargetKeyword    *Keyword
+	IfExistsKeywords []Keyword
+	Names            []Expression
+}
+
+type AlterTableAlterColumnAction struct {
+	Span
+	AlterKeyword  Keyword
+	ColumnKeyword Keyword
+	Column        *ColumnDefinition
+}
+
+// CREATE, ALTER and CREATE OR ALTER PROCEDURE
+type CreateProcedureStatement struct {
+	Span
+	CreateKeywords   []Keyword
+	ProcedureKeyword Keyword
+	Name             Expression
+	Parameters       []RoutineParameter
+	WithKeyword      *Keyword
+	Options          []RoutineOption
+	AsKeyword        Keyword
+	Statements       []Statement
+}
+
+// CREATE, ALTER and CREATE OR ALTER FUNCTION
+type CreateFunctionStatement struct {
+	Span
+	CreateKeywords  []Keyword
+	FunctionKeyword Keyword
+	Name            Expression
+	Parameters      []RoutineParameter
+	ReturnsKeyword  Keyword
+	Type            FunctionType
+	// RETURNS type for scalar functions
+	ReturnType *DataType
+	// RETURNS TABLE for inline table valued functions
+	ReturnTableKeyword *Keyword
+	// RETURNS @t TABLE (...) for multi statement table valued functions
+	ReturnVariable *TableVariableDeclaration
+	WithKeyword    *Keyword
+	Options        []RoutineOption
+	AsKeyword      *Keyword
+	// BEGIN ... END of scalar and multi statement functions
+	Body *BlockStatement
+	// RETURN query of inline functions
+	ReturnKeyword *Keyword
+	ReturnQuery   QueryExpression
+}
+
+type FunctionType uint8
+
+const (
+	FTScalar FunctionType = iota
+	FTInlineTable
+	FTMultiStatementTable
+)
+
+type RoutineParameter struct {
+	Span
+	Name      ExprLocalVariable
+	AsKeyword *Keyword
+	DataType  *DataType
+	// user defined types like table types passed READONLY
+	TypeName Expression
+	Default  Expression
+	// OUTPUT, OUT or READONLY
+	OptionKeywords []Keyword
+}
+
+// RECOMPILE, SCHEMABINDING, EXECUTE AS OWNER, EXECUTE AS 'user'
+type RoutineOption struct {
+	Span
+	Keywords []Keyword
+	Value    Expression
+}
+
+// CREATE, ALTER and CREATE OR ALTER VIEW
+type CreateViewStatement struct {
+	Span
+	CreateKeywords []Keyword
+	ViewKeyword    Keyword
+	Name           Expression
+	Columns        *ExprExpressionList
+	WithKeyword    *Keyword
+	Options        []RoutineOption
+	AsKeyword      Keyword
+	Query          *SelectStatement
+}
+
+type CreateIndexStatement struct {
+	Span
+	CreateKeyword    Keyword
+	UniqueKeyword    *Keyword
+	ClusteredKeyword *Keyword
+	IndexKeyword     Keyword
+	Name             Expression
+	OnKeyword        Keyword
+	Table            Expression
+	Columns          []OrderByArg
+	IncludeKeyword   *Keyword
+	IncludeColumns   *ExprExpressionList
+	WhereClause      *WhereClause
+	WithKeyword      *Keyword
+	Options          []IndexOption
+}
+
+// FILLFACTOR = 80, ONLINE = ON
+type IndexOption struct {
+	Span
+	Name Expression
+	// ON or OFF
+	ValueKeyword *Keyword
+	Value        Expression
+}
+
+// CREATE, ALTER and CREATE OR ALTER TRIGGER
+type CreateTriggerStatement struct {
+	Span
+	CreateKeywords []Keyword
+	TriggerKeyword Keyword
+	Name           Expression
+	OnKeyword      Keyword
+	Table          Expression
+	WithKeyword    *Keyword
+	Options        []RoutineOption
+	// AFTER, FOR or INSTEAD OF
+	TimingKeywords []Keyword
+	// INSERT, UPDATE and DELETE
+	EventKeywords []Keyword
+	AsKeyword     Keyword
+	Statements    []Statement
+}
+
+func (ct CreateTableStatement) statementNode()     {}
+func (at AlterTableStatement) statementNode()      {}
+func (dt DropTableStatement) statementNode()       {}
+func (cp CreateProcedureStatement) statementNode() {}
+func (cf CreateFunctionStatement) statementNode()  {}
+func (cv CreateViewStatement) statementNode()      {}
+func (ci CreateIndexStatement) statementNode()     {}
+func (tr CreateTriggerStatement) statementNode()   {}
+
+func (col *ColumnDefinition) tableElementNode() {}
+func (tc *TableConstraint) tableElementNode()   {}
+
+func (aa *AlterTableAddAction) alterTableActionNode()         {}
+func (ad *AlterTableDropAction) alterTableActionNode()        {}
+func (ac *AlterTableAlterColumnAction) alterTableActionNode() {}
+
+func (ct CreateTableStatement) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s (%s)",
+		ct.CreateKeyword.TokenLiteral(),
+		ct.TableKeyword.TokenLiteral(),
+		ct.Name.TokenLiteral(),
+		tableElementsToString(ct.Definitions),
+	)
+}
+func (at AlterTableStatement) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s %s",
+		at.AlterKeyword.TokenLiteral(),
+		at.TableKeyword.TokenLiteral(),
+		at.Name.TokenLiteral(),
+		at.Action.TokenLiteral(),
+	)
+}
+func (dt DropTableStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s", dt.DropKeyword.TokenLiteral(), dt.TableKeyword.TokenLiteral()))
+	if len(dt.IfExistsKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(dt.IfExistsKeywords)))
+	}
+	str.WriteString(fmt.Sprintf(" %s", expressionListToString(dt.Names, ", ")))
+
+	return str.String()
+}
+func (col ColumnDefinition) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(col.Name.TokenLiteral())
+
+	if col.AsKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", col.AsKeyword.TokenLiteral(), col.ComputedValue.TokenLiteral()))
+		if col.PersistedKeyword != nil {
+			str.WriteString(fmt.Sprintf(" %s", col.PersistedKeyword.TokenLiteral()))
+		}
+	} else {
+		str.WriteString(fmt.Sprintf(" %s", col.DataType.TokenLiteral()))
+	}
+	if col.Identity != nil {
+		str.WriteString(fmt.Sprintf(" %s", col.Identity.TokenLiteral()))
+	}
+	if len(col.NullKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(col.NullKeywords)))
+	}
+	for _, c := range col.Constraints {
+		str.WriteString(fmt.Sprintf(" %s", c.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (ip IdentityProperty) TokenLiteral() string {
+	if ip.Seed == nil {
+		return ip.IdentityKeyword.TokenLiteral()
+	}
+	return fmt.Sprintf("%s(%s, %s)",
+		ip.IdentityKeyword.TokenLiteral(),
+		ip.Seed.TokenLiteral(),
+		ip.Increment.TokenLiteral(),
+	)
+}
+func (tc TableConstraint) TokenLiteral() string {
+	var str strings.Builder
+	if tc.ConstraintKeyword != nil {
+		str.WriteString(fmt.Sprintf("%s %s ", tc.ConstraintKeyword.TokenLiteral(), tc.Name.TokenLiteral()))
+	}
+	str.WriteString(keywordsToString(tc.TypeKeywords))
+
+	if tc.ClusteredKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", tc.ClusteredKeyword.TokenLiteral()))
+	}
+	if len(tc.Columns) > 0 {
+		var columns []string
+		for _, c := range tc.Columns {
+			columns = append(columns, c.TokenLiteral())
+		}
+		str.WriteString(fmt.Sprintf(" (%s)", strings.Join(columns, ", ")))
+	}
+	if tc.ReferencesKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", tc.ReferencesKeyword.TokenLiteral(), tc.ReferencedTable.TokenLiteral()))
+		if tc.ReferencedColumns != nil {
+			str.WriteString(fmt.Sprintf(" (%s)", tc.ReferencedColumns.TokenLiteral()))
+		}
+	}
+	if len(tc.OnDeleteKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(tc.OnDeleteKeywords)))
+	}
+	if len(tc.OnUpdateKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(tc.OnUpdateKeywords)))
+	}
+	if tc.Type == CTCheck {
+		str.WriteString(fmt.Sprintf(" (%s)", tc.Expression.TokenLiteral()))
+	} else if tc.Type == CTDefault {
+		str.WriteString(fmt.Sprintf(" %s", tc.Expression.TokenLiteral()))
+	}
+	if tc.ForKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", tc.ForKeyword.TokenLiteral(), tc.ForColumn.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (aa AlterTableAddAction) TokenLiteral() string {
+	return fmt.Sprintf("%s %s", aa.AddKeyword.TokenLiteral(), tableElementsToString(aa.Definitions))
+}
+func (ad AlterTableDropAction) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(ad.DropKeyword.TokenLiteral())
+	if ad.TargetKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ad.TargetKeyword.TokenLiteral()))
+	}
+	if len(ad.IfExistsKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(ad.IfExistsKeywords)))
+	}
+	str.WriteString(fmt.Sprintf(" %s", expressionListToString(ad.Names, ", ")))
+
+	return str.String()
+}
+func (ac AlterTableAlterColumnAction) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s",
+		ac.AlterKeyword.TokenLiteral(),
+		ac.ColumnKeyword.TokenLiteral(),
+		ac.Column.TokenLiteral(),
+	)
+}
+
+func (cp CreateProcedureStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s %s",
+		keywordsToString(cp.CreateKeywords),
+		cp.ProcedureKeyword.TokenLiteral(),
+		cp.Name.TokenLiteral(),
+	))
+	if len(cp.Parameters) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", routineParametersToString(cp.Parameters)))
+	}
+	str.WriteString(routineOptionsToString(cp.WithKeyword, cp.Options))
+	str.WriteString(fmt.Sprintf(" %s", cp.AsKeyword.TokenLiteral()))
+	for _, s := range cp.Statements {
+		str.WriteString(fmt.Sprintf(" %s", s.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (cf CreateFunctionStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s %s(%s) %s",
+		keywordsToString(cf.CreateKeywords),
+		cf.FunctionKeyword.TokenLiteral(),
+		cf.Name.TokenLiteral(),
+		routineParametersToString(cf.Parameters),
+		cf.ReturnsKeyword.TokenLiteral(),
+	))
+	switch cf.Type {
+	case FTScalar:
+		str.WriteString(fmt.Sprintf(" %s", cf.ReturnType.TokenLiteral()))
+	case FTInlineTable:
+		str.WriteString(fmt.Sprintf(" %s", cf.ReturnTableKeyword.TokenLiteral()))
+	case FTMultiStatementTable:
+		str.WriteString(fmt.Sprintf(" %s", cf.ReturnVariable.TokenLiteral()))
+	}
+	str.WriteString(routineOptionsToString(cf.WithKeyword, cf.Options))
+	if cf.AsKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", cf.AsKeyword.TokenLiteral()))
+	}
+	if cf.Type == FTInlineTable {
+		str.WriteString(fmt.Sprintf(" %s %s", cf.ReturnKeyword.TokenLiteral(), cf.ReturnQuery.TokenLiteral()))
+	} else {
+		str.WriteString(fmt.Sprintf(" %s", cf.Body.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (rp RoutineParameter) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(rp.Name.TokenLiteral())
+	if rp.AsKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", rp.AsKeyword.TokenLiteral()))
+	}
+	if rp.DataType != nil {
+		str.WriteString(fmt.Sprintf(" %s", rp.DataType.TokenLiteral()))
+	} else {
+		str.WriteString(fmt.Sprintf(" %s", rp.TypeName.TokenLiteral()))
+	}
+	if rp.Default != nil {
+		str.WriteString(fmt.Sprintf(" = %s", rp.Default.TokenLiteral()))
+	}
+	if len(rp.OptionKeywords) > 0 {
+		str.WriteString(fmt.Sprintf(" %s", keywordsToString(rp.OptionKeywords)))
+	}
+
+	return str.String()
+}
+func (ro RoutineOption) TokenLiteral() string {
+	if ro.Value != nil {
+		return fmt.Sprintf("%s %s", keywordsToString(ro.Keywords), ro.Value.TokenLiteral())
+	}
+	return keywordsToString(ro.Keywords)
+}
+
+func (cv CreateViewStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s %s",
+		keywordsToString(cv.CreateKeywords),
+		cv.ViewKeyword.TokenLiteral(),
+		cv.Name.TokenLiteral(),
+	))
+	if cv.Columns != nil {
+		str.WriteString(fmt.Sprintf(" (%s)", cv.Columns.TokenLiteral()))
+	}
+	str.WriteString(routineOptionsToString(cv.WithKeyword, cv.Options))
+	str.WriteString(fmt.Sprintf(" %s %s", cv.AsKeyword.TokenLiteral(), cv.Query.TokenLiteral()))
+
+	return str.String()
+}
+func (ci CreateIndexStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(ci.CreateKeyword.TokenLiteral())
+	if ci.UniqueKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ci.UniqueKeyword.TokenLiteral()))
+	}
+	if ci.ClusteredKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ci.ClusteredKeyword.TokenLiteral()))
+	}
+
+	var columns []string
+	for _, c := range ci.Columns {
+		columns = append(columns, c.TokenLiteral())
+	}
+	str.WriteString(fmt.Sprintf(" %s %s %s %s (%s)",
+		ci.IndexKeyword.TokenLiteral(),
+		ci.Name.TokenLiteral(),
+		ci.OnKeyword.TokenLiteral(),
+		ci.Table.TokenLiteral(),
+		strings.Join(columns, ", "),
+	))
+	if ci.IncludeKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s (%s)", ci.IncludeKeyword.TokenLiteral(), ci.IncludeColumns.TokenLiteral()))
+	}
+	if ci.WhereClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", ci.WhereClause.TokenLiteral()))
+	}
+	if ci.WithKeyword != nil {
+		var options []string
+		for _, o := range ci.Options {
+			options = append(options, o.TokenLiteral())
+		}
+		str.WriteString(fmt.Sprintf(" %s (%s)", ci.WithKeyword.TokenLiteral(), strings.Join(options, ", ")))
+	}
+
+	return str.String()
+}
+func (io IndexOption) TokenLiteral() string {
+	if io.ValueKeyword != nil {
+		return fmt.Sprintf("%s = %s", io.Name.TokenLiteral(), io.ValueKeyword.TokenLiteral())
+	}
+	return fmt.Sprintf("%s = %s", io.Name.TokenLiteral(), io.Value.TokenLiteral())
+}
+func (tr CreateTriggerStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s %s %s %s",
+		keywordsToString(tr.CreateKeywords),
+		tr.TriggerKeyword.TokenLiteral(),
+		tr.Name.TokenLiteral(),
+		tr.OnKeyword.TokenLiteral(),
+		tr.Table.TokenLiteral(),
+	))
+	str.WriteString(routineOptionsToString(tr.WithKeyword, tr.Options))
+
+	var events []string
+	for _, k := range tr.EventKeywords {
+		events = append(events, k.TokenLiteral())
+	}
+	str.WriteString(fmt.Sprintf(" %s %s %s",
+		keywordsToString(tr.TimingKeywords),
+		strings.Join(events, ", "),
+		tr.AsKeyword.TokenLiteral(),
+	))
+	for _, s := range tr.Statements {
+		str.WriteString(fmt.Sprintf(" %s", s.TokenLiteral()))
+	}
+
+	return str.String()
+}
+
+func (ct *CreateTableStatement) GetSpan() Span        { return ct.Span }
+func (at *AlterTableStatement) GetSpan() Span         { return at.Span }
+func (dt *DropTableStatement) GetSpan() Span          { return dt.Span }
+func (col *ColumnDefinition) GetSpan() Span           { return col.Span }
+func (ip *IdentityProperty) GetSpan() Span            { return ip.Span }
+func (tc *TableConstraint) GetSpan() Span             { return tc.Span }
+func (aa *AlterTableAddAction) GetSpan() Span         { return aa.Span }
+func (ad *AlterTableDropAction) GetSpan() Span        { return ad.Span }
+func (ac *AlterTableAlterColumnAction) GetSpan() Span { return ac.Span }
+func (cp *CreateProcedureStatement) GetSpan() Span    { return cp.Span }
+func (cf *CreateFunctionStatement) GetSpan() Span     { return cf.Span }
+func (rp *RoutineParameter) GetSpan() Span            { return rp.Span }
+func (ro *RoutineOption) GetSpan() Span               { return ro.Span }
+func (cv *CreateViewStatement) GetSpan() Span         { return cv.Span }
+func (ci *CreateIndexStatement) GetSpan() Span        { return ci.Span }
+func (io *IndexOption) GetSpan() Span                 { return io.Span }
+func (tr *CreateTriggerStatement) GetSpan() Span      { return tr.Span }
+
+func (ct *CreateTableStatement) SetSpan(span Span)        { ct.Span = span }
+func (at *AlterTableStatement) SetSpan(span Span)         { at.Span = span }
+func (dt *DropTableStatement) SetSpan(span Span)          { dt.Span = span }
+func (col *ColumnDefinition) SetSpan(span Span)           { col.Span = span }
+func (ip *IdentityProperty) SetSpan(span Span)            { ip.Span = span }
+func (tc *TableConstraint) SetSpan(span Span)             { tc.Span = span }
+func (aa *AlterTableAddAction) SetSpan(span Span)         { aa.Span = span }
+func (ad *AlterTableDropAction) SetSpan(span Span)        { ad.Span = span }
+func (ac *AlterTableAlterColumnAction) SetSpan(span Span) { ac.Span = span }
+func (cp *CreateProcedureStatement) SetSpan(span Span)    { cp.Span = span }
+func (cf *CreateFunctionStatement) SetSpan(span Span)     { cf.Span = span }
+func (rp *RoutineParameter) SetSpan(span Span)            { rp.Span = span }
+func (ro *RoutineOption) SetSpan(span Span)               { ro.Span = span }
+func (cv *CreateViewStatement) SetSpan(span Span)         { cv.Span = span }
+func (ci *CreateIndexStatement) SetSpan(span Span)        { ci.Span = span }
+func (io *IndexOption) SetSpan(span Span)                 { io.Span = span }
+func (tr *CreateTriggerStatement) SetSpan(span Span)      { tr.Span = span }
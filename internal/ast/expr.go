@@ -23,12 +23,13 @@ var BuiltinFunctionsTokenType = []lexer.TokenType{
 	lexer.TSign, lexer.TSin, lexer.TSqrt, lexer.TSquare, lexer.TTan, lexer.TFirstValue,
 	lexer.TLastValue, lexer.TLag, lexer.TLead, lexer.TAvg, lexer.TCount, lexer.TMax, lexer.TMin,
 	lexer.TStdev, lexer.TStdevp, lexer.TSum, lexer.TVar, lexer.TVarp, lexer.TGetdate,
-	lexer.TChecksum, lexer.TNewId,
+	lexer.TChecksum, lexer.TNewId, lexer.TGrouping, lexer.TGroupingId,
 }
 
 type ExprStringLiteral struct {
 	Span
-	Value string
+	Value   string
+	Unicode bool
 }
 
 type ExprNumberLiteral struct {
@@ -65,6 +66,7 @@ type ExprWithAlias struct {
 type ExprCompoundIdentifier struct {
 	Span
 	Identifiers []Expression
+	PseudoTable PseudoTable
 }
 
 type ExprBuiltInFunctionName struct {
@@ -72,6 +74,12 @@ type ExprBuiltInFunctionName struct {
 	Value string
 }
 
+// the first argument of DATEADD, DATEDIFF and DATEPART, `day` or `dd`
+type ExprDatePart struct {
+	Span
+	Value string
+}
+
 type SelectItems struct {
 	Span
 	Items []Expression
@@ -89,14 +97,40 @@ type HavingClause struct {
 	Clause        Expression
 }
 
+type IntoClause struct {
+	Span
+	IntoKeyword Keyword
+	Target      Expression
+}
+
 type GroupByClause struct {
 	Span
 	GroupByKeyword [2]Keyword
 	Items          []Expression
 }
 
+// ROLLUP (a, b), CUBE (a, b) or GROUPING SETS ((a, b), a, ())
+type ExprGrouping struct {
+	Span
+	Type     GroupingType
+	Keywords []Keyword
+	Sets     []Expression
+}
+
+// columns grouped together inside a grouping, `()` is the grand total
+type ExprGroupingSet struct {
+	Span
+	Items []Expression
+}
+
 type ExprSubquery struct {
-	SelectBody
+	Span
+	Query QueryExpression
+}
+
+// a parenthesized VALUES list used as a derived table
+type ExprValuesTable struct {
+	TableValueConstructor
 }
 
 type TopArg struct {
@@ -105,6 +139,7 @@ type TopArg struct {
 	WithTiesKeyword *[2]Keyword
 	PercentKeyword  *Keyword
 	Quantity        Expression
+	Parenthesized   bool
 }
 
 type TableArg struct {
@@ -116,8 +151,81 @@ type TableArg struct {
 
 type TableSource struct {
 	Span
-	Type   TableSourceType
-	Source Expression
+	Type        TableSourceType
+	Source      Expression
+	Columns     *ExprExpressionList
+	WithKeyword *Keyword
+	Hints       []TableHint
+}
+
+// NOLOCK, INDEX(IX_Quotes) or FORCESEEK in the WITH after a table
+type TableHint struct {
+	Span
+	HintKeyword Keyword
+	// the indexes of an INDEX hint
+	Indexes []Expression
+	// the index columns of FORCESEEK(IX_Quotes (Symbol))
+	Columns *ExprExpressionList
+}
+
+// OPTION (RECOMPILE, MAXDOP 1) at the end of a query
+type OptionClause struct {
+	Span
+	OptionKeyword Keyword
+	Hints         []QueryHint
+}
+
+type QueryHint struct {
+	Span
+	// RECOMPILE, MAXDOP, OPTIMIZE FOR, HASH JOIN...
+	Keywords []Keyword
+	// the number of MAXDOP, FAST and MAXRECURSION
+	Value Expression
+	// the variables of OPTIMIZE FOR (...)
+	Variables []OptimizeForVariable
+}
+
+// @p UNKNOWN or @p = 5 in OPTIMIZE FOR
+type OptimizeForVariable struct {
+	Span
+	Variable       ExprLocalVariable
+	UnknownKeyword *Keyword
+	Value          Expression
+}
+
+// FOR XML PATH('row'), TYPE or FOR JSON PATH, ROOT('data') at the end of a query
+type ForClause struct {
+	Span
+	ForKeyword  Keyword
+	ModeKeyword Keyword
+	Options     []ForOption
+}
+
+// PATH('row'), ELEMENTS XSINIL or INCLUDE_NULL_VALUES
+type ForOption struct {
+	Span
+	Keywords []Keyword
+	Value    Expression
+}
+
+// OPENJSON(@json, '$.items') WITH (...) or OPENXML(@doc, '/rows/row', 2) WITH (...)
+type ExprRowsetFunction struct {
+	Span
+	FunctionKeyword Keyword
+	Args            []Expression
+	WithKeyword     *Keyword
+	Columns         []RowsetColumn
+	// OPENXML can take its columns from an existing table
+	SchemaTable Expression
+}
+
+// `id INT '$.id'` or `tags NVARCHAR(MAX) '$.tags' AS JSON`
+type RowsetColumn struct {
+	Span
+	Name          Expression
+	DataType      DataType
+	Path          Expression
+	AsJsonKeyword *[2]Keyword
 }
 
 type Join struct {
@@ -208,9 +316,18 @@ type ExprFunction struct {
 
 type ExprFunctionCall struct {
 	Span
-	Name       *ExprFunction
-	Args       []Expression
-	OverClause *FunctionOverClause
+	Name              *ExprFunction
+	Args              []Expression
+	WithinGroupClause *WithinGroupClause
+	OverClause        *FunctionOverClause
+}
+
+// WITHIN GROUP (ORDER BY x) after an ordered set aggregate like STRING_AGG
+type WithinGroupClause struct {
+	Span
+	WithinGroupKeyword [2]Keyword
+	OrderByKeyword     [2]Keyword
+	OrderByClause      []OrderByArg
 }
 
 type ExprCast struct {
@@ -221,12 +338,87 @@ type ExprCast struct {
 	DataType    DataType
 }
 
+type ExprConvert struct {
+	Span
+	ConvertKeyword Keyword
+	DataType       DataType
+	Expression     Expression
+	Style          Expression
+}
+
+type ExprParse struct {
+	Span
+	ParseKeyword Keyword
+	Expression   Expression
+	AsKeyword    Keyword
+	DataType     DataType
+	UsingKeyword *Keyword
+	Culture      Expression
+}
+
+type ExprCase struct {
+	Span
+	CaseKeyword     Keyword
+	InputExpression Expression
+	WhenClauses     []WhenClause
+	ElseClause      *ElseClause
+	EndKeyword      Keyword
+}
+
+type WhenClause struct {
+	Span
+	WhenKeyword Keyword
+	Condition   Expression
+	ThenKeyword Keyword
+	Result      Expression
+}
+
+type ElseClause struct {
+	Span
+	ElseKeyword Keyword
+	Result      Expression
+}
+
 type CommonTableExpression struct {
 	Span
 	Name      string
 	Columns   *ExprExpressionList
 	AsKeyword Keyword
-	Query     SelectBody
+	Query     QueryExpression
+}
+
+type ExprNullLiteral struct {
+	Span
+}
+
+type TableValueConstructor struct {
+	Span
+	ValuesKeyword Keyword
+	Rows          []ExprExpressionList
+}
+
+type OutputClause struct {
+	Span
+	OutputKeyword Keyword
+	Columns       SelectItems
+	IntoKeyword   *Keyword
+	IntoTarget    Expression
+	IntoColumns   *ExprExpressionList
+}
+
+// src PIVOT (SUM(Amount) FOR [Month] IN ([Jan], [Feb])) and
+// src UNPIVOT (Amount FOR [Month] IN ([Jan], [Feb]))
+type ExprPivot struct {
+	Span
+	Source Expression
+	// PIVOT or UNPIVOT
+	PivotKeyword Keyword
+	// the aggregate of a pivot or the value column of an unpivot
+	Aggregate  Expression
+	ForKeyword Keyword
+	Column     Expression
+	InKeyword  Keyword
+	Values     []Expression
 }
 
 func (e ExprStringLiteral) expressionNode()       {}
@@ -238,10 +430,14 @@ func (e ExprStar) expressionNode()                {}
 func (e ExprWithAlias) expressionNode()           {}
 func (e ExprCompoundIdentifier) expressionNode()  {}
 func (e ExprBuiltInFunctionName) expressionNode() {}
+func (e ExprDatePart) expressionNode()            {}
 func (si SelectItems) expressionNode()            {}
 func (w WhereClause) expressionNode()             {}
 func (h HavingClause) expressionNode()            {}
+func (i IntoClause) expressionNode()              {}
 func (gb GroupByClause) expressionNode()          {}
+func (e ExprGrouping) expressionNode()            {}
+func (e ExprGroupingSet) expressionNode()         {}
 func (ta TableArg) expressionNode()               {}
 func (ts TableSource) expressionNode()            {}
 func (j Join) expressionNode()                    {}
@@ -252,16 +448,38 @@ func (o OffsetArg) expressionNode()               {}
 func (f FetchArg) expressionNode()                {}
 func (o OffsetFetchClause) expressionNode()       {}
 func (e ExprSubquery) expressionNode()            {}
+func (e ExprValuesTable) expressionNode()         {}
 func (e ExprExpressionList) expressionNode()      {}
 func (e ExprFunction) expressionNode()            {}
 func (w WindowFrameBound) expressionNode()        {}
 func (w WindowFrameClause) expressionNode()       {}
 func (e FunctionOverClause) expressionNode()      {}
+func (wg WithinGroupClause) expressionNode()      {}
 func (e ExprFunctionCall) expressionNode()        {}
 func (e ExprCast) expressionNode()                {}
+func (e ExprConvert) expressionNode()             {}
+func (e ExprParse) expressionNode()               {}
+func (e ExprCase) expressionNode()                {}
+func (w WhenClause) expressionNode()              {}
+func (e ElseClause) expressionNode()              {}
 func (cte CommonTableExpression) expressionNode() {}
+func (e ExprNullLiteral) expressionNode()         {}
+func (e TableValueConstructor) expressionNode()   {}
+func (oc OutputClause) expressionNode()           {}
+func (e ExprPivot) expressionNode()               {}
+func (th TableHint) expressionNode()              {}
+func (oc OptionClause) expressionNode()           {}
+func (qh QueryHint) expressionNode()              {}
+func (ov OptimizeForVariable) expressionNode()    {}
+func (fc ForClause) expressionNode()              {}
+func (fo ForOption) expressionNode()              {}
+func (e ExprRowsetFunction) expressionNode()      {}
+func (rc RowsetColumn) expressionNode()           {}
 
 func (e ExprStringLiteral) TokenLiteral() string {
+	if e.Unicode {
+		return fmt.Sprintf("N'%s'", e.Value)
+	}
 	return fmt.Sprintf("'%s'", e.Value)
 }
 func (e ExprNumberLiteral) TokenLiteral() string {
@@ -303,6 +521,9 @@ func (e ExprCompoundIdentifier) TokenLiteral() string {
 func (e ExprBuiltInFunctionName) TokenLiteral() string {
 	return e.Value
 }
+func (e ExprDatePart) TokenLiteral() string {
+	return e.Value
+}
 func (si SelectItems) TokenLiteral() string {
 	return expressionListToString(si.Items, ", ")
 }
@@ -320,6 +541,9 @@ func (h HavingClause) TokenLiteral() string {
 
 	return str.String()
 }
+func (i IntoClause) TokenLiteral() string {
+	return fmt.Sprintf(" %s %s", i.IntoKeyword.TokenLiteral(), i.Target.TokenLiteral())
+}
 func (gb GroupByClause) TokenLiteral() string {
 	var str strings.Builder
 	str.WriteString(" ")
@@ -330,6 +554,18 @@ func (gb GroupByClause) TokenLiteral() string {
 
 	return str.String()
 }
+func (e ExprGrouping) TokenLiteral() string {
+	var str strings.Builder
+	for _, k := range e.Keywords {
+		str.WriteString(fmt.Sprintf("%s ", k.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf("(%s)", expressionListToString(e.Sets, ", ")))
+
+	return str.String()
+}
+func (e ExprGroupingSet) TokenLiteral() string {
+	return fmt.Sprintf("(%s)", expressionListToString(e.Items, ", "))
+}
 func (ta TableArg) TokenLiteral() string {
 	var str strings.Builder
 
@@ -354,6 +590,121 @@ func (ts TableSource) TokenLiteral() string {
 	var str strings.Builder
 
 	str.WriteString(ts.Source.TokenLiteral())
+	if ts.Columns != nil {
+		str.WriteString(fmt.Sprintf("(%s)", ts.Columns.TokenLiteral()))
+	}
+	if ts.WithKeyword != nil {
+		hints := []string{}
+		for _, h := range ts.Hints {
+			hints = append(hints, h.TokenLiteral())
+		}
+		str.WriteString(fmt.Sprintf(" %s (%s)", ts.WithKeyword.TokenLiteral(), strings.Join(hints, ", ")))
+	}
+
+	return str.String()
+}
+func (th TableHint) TokenLiteral() string {
+	if len(th.Indexes) == 0 {
+		return th.HintKeyword.TokenLiteral()
+	}
+	if th.Columns != nil {
+		return fmt.Sprintf("%s(%s (%s))", th.HintKeyword.TokenLiteral(), th.Indexes[0].TokenLiteral(), th.Columns.TokenLiteral())
+	}
+
+	return fmt.Sprintf("%s(%s)", th.HintKeyword.TokenLiteral(), expressionListToString(th.Indexes, ", "))
+}
+func (oc OptionClause) TokenLiteral() string {
+	hints := []string{}
+	for _, h := range oc.Hints {
+		hints = append(hints, h.TokenLiteral())
+	}
+
+	return fmt.Sprintf(" %s (%s)", oc.OptionKeyword.TokenLiteral(), strings.Join(hints, ", "))
+}
+func (qh QueryHint) TokenLiteral() string {
+	var str strings.Builder
+
+	for i, k := range qh.Keywords {
+		if i > 0 {
+			str.WriteString(" ")
+		}
+		str.WriteString(k.TokenLiteral())
+	}
+	if qh.Value != nil {
+		str.WriteString(fmt.Sprintf(" %s", qh.Value.TokenLiteral()))
+	}
+	if len(qh.Variables) > 0 {
+		variables := []string{}
+		for _, v := range qh.Variables {
+			variables = append(variables, v.TokenLiteral())
+		}
+		str.WriteString(fmt.Sprintf(" (%s)", strings.Join(variables, ", ")))
+	}
+
+	return str.String()
+}
+func (ov OptimizeForVariable) TokenLiteral() string {
+	if ov.UnknownKeyword != nil {
+		return fmt.Sprintf("%s %s", ov.Variable.TokenLiteral(), ov.UnknownKeyword.TokenLiteral())
+	}
+
+	return fmt.Sprintf("%s = %s", ov.Variable.TokenLiteral(), ov.Value.TokenLiteral())
+}
+func (fc ForClause) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf(" %s %s", fc.ForKeyword.TokenLiteral(), fc.ModeKeyword.TokenLiteral()))
+	for i, o := range fc.Options {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString(fmt.Sprintf(" %s", o.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (fo ForOption) TokenLiteral() string {
+	var str strings.Builder
+	for i, k := range fo.Keywords {
+		if i > 0 {
+			str.WriteString(" ")
+		}
+		str.WriteString(k.TokenLiteral())
+	}
+	if fo.Value != nil {
+		str.WriteString(fmt.Sprintf("(%s)", fo.Value.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (e ExprRowsetFunction) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s(%s)", e.FunctionKeyword.TokenLiteral(), expressionListToString(e.Args, ", ")))
+	if e.WithKeyword == nil {
+		return str.String()
+	}
+
+	str.WriteString(fmt.Sprintf(" %s ", e.WithKeyword.TokenLiteral()))
+	if e.SchemaTable != nil {
+		str.WriteString(e.SchemaTable.TokenLiteral())
+		return str.String()
+	}
+	columns := []string{}
+	for _, c := range e.Columns {
+		columns = append(columns, c.TokenLiteral())
+	}
+	str.WriteString(fmt.Sprintf("(%s)", strings.Join(columns, ", ")))
+
+	return str.String()
+}
+func (rc RowsetColumn) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s", rc.Name.TokenLiteral(), rc.DataType.TokenLiteral()))
+	if rc.Path != nil {
+		str.WriteString(fmt.Sprintf(" %s", rc.Path.TokenLiteral()))
+	}
+	if rc.AsJsonKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", rc.AsJsonKeyword[0].TokenLiteral(), rc.AsJsonKeyword[1].TokenLiteral()))
+	}
 
 	return str.String()
 }
@@ -379,7 +730,11 @@ func (j Join) TokenLiteral() string {
 }
 func (ta TopArg) TokenLiteral() string {
 	var str strings.Builder
-	str.WriteString(fmt.Sprintf("%s %s", ta.TopKeyword.TokenLiteral(), ta.Quantity.TokenLiteral()))
+	if ta.Parenthesized {
+		str.WriteString(fmt.Sprintf("%s (%s)", ta.TopKeyword.TokenLiteral(), ta.Quantity.TokenLiteral()))
+	} else {
+		str.WriteString(fmt.Sprintf("%s %s", ta.TopKeyword.TokenLiteral(), ta.Quantity.TokenLiteral()))
+	}
 
 	if ta.PercentKeyword != nil {
 		str.WriteString(fmt.Sprintf(" %s", ta.PercentKeyword.TokenLiteral()))
@@ -459,11 +814,14 @@ func (f FetchArg) TokenLiteral() string {
 
 	return str.String()
 }
+func (e ExprValuesTable) TokenLiteral() string {
+	return fmt.Sprintf("(%s)", e.TableValueConstructor.TokenLiteral())
+}
 func (e ExprSubquery) TokenLiteral() string {
 	var str strings.Builder
 	str.WriteString("(")
 
-	str.WriteString(e.SelectBody.TokenLiteral())
+	str.WriteString(e.Query.TokenLiteral())
 
 	str.WriteString(")")
 	return str.String()
@@ -563,7 +921,11 @@ func (e ExprFunction) TokenLiteral() string {
 		return "CHECKSUM"
 	case FuncNewId:
 		return "NEWID"
-	case FuncUserDefined:
+	case FuncGrouping:
+		return "GROUPING"
+	case FuncGroupingId:
+		return "GROUPING_ID"
+	case FuncSystem, FuncUserDefined:
 		return e.Name.TokenLiteral()
 	default:
 		return "unimplemented function type"
@@ -685,12 +1047,29 @@ func (e ExprFunctionCall) TokenLiteral() string {
 	}
 	str.WriteString(")")
 
+	if e.WithinGroupClause != nil {
+		str.WriteString(e.WithinGroupClause.TokenLiteral())
+	}
 	if e.OverClause != nil {
 		str.WriteString(e.OverClause.TokenLiteral())
 	}
 
 	return str.String()
 }
+func (wg WithinGroupClause) TokenLiteral() string {
+	args := []string{}
+	for _, o := range wg.OrderByClause {
+		args = append(args, o.TokenLiteral())
+	}
+
+	return fmt.Sprintf(" %s %s (%s %s %s)",
+		wg.WithinGroupKeyword[0].TokenLiteral(),
+		wg.WithinGroupKeyword[1].TokenLiteral(),
+		wg.OrderByKeyword[0].TokenLiteral(),
+		wg.OrderByKeyword[1].TokenLiteral(),
+		strings.Join(args, ", "),
+	)
+}
 func (e ExprCast) TokenLiteral() string {
 	var str strings.Builder
 
@@ -702,6 +1081,63 @@ func (e ExprCast) TokenLiteral() string {
 
 	return str.String()
 }
+func (e ExprConvert) TokenLiteral() string {
+	var str strings.Builder
+
+	str.WriteString(fmt.Sprintf("%s(", e.ConvertKeyword.TokenLiteral()))
+	str.WriteString(e.DataType.TokenLiteral())
+	str.WriteString(fmt.Sprintf(", %s", e.Expression.TokenLiteral()))
+	if e.Style != nil {
+		str.WriteString(fmt.Sprintf(", %s", e.Style.TokenLiteral()))
+	}
+	str.WriteString(")")
+
+	return str.String()
+}
+func (e ExprParse) TokenLiteral() string {
+	var str strings.Builder
+
+	str.WriteString(fmt.Sprintf("%s(", e.ParseKeyword.TokenLiteral()))
+	str.WriteString(e.Expression.TokenLiteral())
+	str.WriteString(fmt.Sprintf(" %s ", e.AsKeyword.TokenLiteral()))
+	str.WriteString(e.DataType.TokenLiteral())
+	if e.UsingKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", e.UsingKeyword.TokenLiteral(), e.Culture.TokenLiteral()))
+	}
+	str.WriteString(")")
+
+	return str.String()
+}
+func (e ExprCase) TokenLiteral() string {
+	var str strings.Builder
+
+	str.WriteString(e.CaseKeyword.TokenLiteral())
+	if e.InputExpression != nil {
+		str.WriteString(fmt.Sprintf(" %s", e.InputExpression.TokenLiteral()))
+	}
+	for _, w := range e.WhenClauses {
+		str.WriteString(fmt.Sprintf(" %s", w.TokenLiteral()))
+	}
+	if e.ElseClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", e.ElseClause.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s", e.EndKeyword.TokenLiteral()))
+
+	return str.String()
+}
+func (w WhenClause) TokenLiteral() string {
+	var str strings.Builder
+
+	str.WriteString(fmt.Sprintf("%s ", w.WhenKeyword.TokenLiteral()))
+	str.WriteString(w.Condition.TokenLiteral())
+	str.WriteString(fmt.Sprintf(" %s ", w.ThenKeyword.TokenLiteral()))
+	str.WriteString(w.Result.TokenLiteral())
+
+	return str.String()
+}
+func (e ElseClause) TokenLiteral() string {
+	return fmt.Sprintf("%s %s", e.ElseKeyword.TokenLiteral(), e.Result.TokenLiteral())
+}
 func (cte *CommonTableExpression) TokenLiteral() string {
 	var str strings.Builder
 	str.WriteString(fmt.Sprintf("%s", cte.Name))
@@ -716,6 +1152,50 @@ func (cte *CommonTableExpression) TokenLiteral() string {
 	str.WriteString(" )")
 	return str.String()
 }
+func (e ExprNullLiteral) TokenLiteral() string {
+	return "NULL"
+}
+func (e TableValueConstructor) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(e.ValuesKeyword.TokenLiteral())
+	for i, row := range e.Rows {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString(fmt.Sprintf(" (%s)", row.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (oc OutputClause) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s", oc.OutputKeyword.TokenLiteral(), oc.Columns.TokenLiteral()))
+
+	if oc.IntoKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", oc.IntoKeyword.TokenLiteral(), oc.IntoTarget.TokenLiteral()))
+		if oc.IntoColumns != nil {
+			str.WriteString(fmt.Sprintf(" (%s)", oc.IntoColumns.TokenLiteral()))
+		}
+	}
+
+	return str.String()
+}
+func (e ExprPivot) TokenLiteral() string {
+	values := []string{}
+	for _, v := range e.Values {
+		values = append(values, v.TokenLiteral())
+	}
+
+	return fmt.Sprintf("%s %s (%s %s %s %s (%s))",
+		e.Source.TokenLiteral(),
+		e.PivotKeyword.TokenLiteral(),
+		e.Aggregate.TokenLiteral(),
+		e.ForKeyword.TokenLiteral(),
+		e.Column.TokenLiteral(),
+		e.InKeyword.TokenLiteral(),
+		strings.Join(values, ", "),
+	)
+}
 
 func (e *ExprStringLiteral) SetSpan(span Span)       { e.Span = span }
 func (e *ExprNumberLiteral) SetSpan(span Span)       { e.Span = span }
@@ -726,10 +1206,14 @@ func (e *ExprStar) SetSpan(span Span)                { e.Span = span }
 func (e *ExprWithAlias) SetSpan(span Span)           { e.Span = span }
 func (e *ExprCompoundIdentifier) SetSpan(span Span)  { e.Span = span }
 func (e *ExprBuiltInFunctionName) SetSpan(span Span) { e.Span = span }
+func (e *ExprDatePart) SetSpan(span Span)            { e.Span = span }
 func (si *SelectItems) SetSpan(span Span)            { si.Span = span }
 func (w *WhereClause) SetSpan(span Span)             { w.Span = span }
 func (h *HavingClause) SetSpan(span Span)            { h.Span = span }
+func (i *IntoClause) SetSpan(span Span)              { i.Span = span }
 func (gb *GroupByClause) SetSpan(span Span)          { gb.Span = span }
+func (e *ExprGrouping) SetSpan(span Span)            { e.Span = span }
+func (e *ExprGroupingSet) SetSpan(span Span)         { e.Span = span }
 func (ta *TableArg) SetSpan(span Span)               { ta.Span = span }
 func (ts *TableSource) SetSpan(span Span)            { ts.Span = span }
 func (j *Join) SetSpan(span Span)                    { j.Span = span }
@@ -740,14 +1224,33 @@ func (o *OrderByArg) SetSpan(span Span)              { o.Span = span }
 func (o *OffsetArg) SetSpan(span Span)               { o.Span = span }
 func (f *FetchArg) SetSpan(span Span)                { f.Span = span }
 func (e *ExprSubquery) SetSpan(span Span)            { e.Span = span }
+func (e *ExprValuesTable) SetSpan(span Span)         { e.Span = span }
 func (e *ExprExpressionList) SetSpan(span Span)      { e.Span = span }
 func (e *ExprFunction) SetSpan(span Span)            { e.Span = span }
 func (w *WindowFrameBound) SetSpan(span Span)        { w.Span = span }
 func (w *WindowFrameClause) SetSpan(span Span)       { w.Span = span }
 func (e *FunctionOverClause) SetSpan(span Span)      { e.Span = span }
+func (wg *WithinGroupClause) SetSpan(span Span)      { wg.Span = span }
 func (e *ExprFunctionCall) SetSpan(span Span)        { e.Span = span }
 func (e *ExprCast) SetSpan(span Span)                { e.Span = span }
+func (e *ExprConvert) SetSpan(span Span)             { e.Span = span }
+func (e *ExprParse) SetSpan(span Span)               { e.Span = span }
+func (e *ExprCase) SetSpan(span Span)                { e.Span = span }
+func (w *WhenClause) SetSpan(span Span)              { w.Span = span }
+func (e *ElseClause) SetSpan(span Span)              { e.Span = span }
 func (cte *CommonTableExpression) SetSpan(span Span) { cte.Span = span }
+func (e *ExprNullLiteral) SetSpan(span Span)         { e.Span = span }
+func (e *TableValueConstructor) SetSpan(span Span)   { e.Span = span }
+func (oc *OutputClause) SetSpan(span Span)           { oc.Span = span }
+func (e *ExprPivot) SetSpan(span Span)               { e.Span = span }
+func (th *TableHint) SetSpan(span Span)              { th.Span = span }
+func (oc *OptionClause) SetSpan(span Span)           { oc.Span = span }
+func (qh *QueryHint) SetSpan(span Span)              { qh.Span = span }
+func (ov *OptimizeForVariable) SetSpan(span Span)    { ov.Span = span }
+func (fc *ForClause) SetSpan(span Span)              { fc.Span = span }
+func (fo *ForOption) SetSpan(span Span)              { fo.Span = span }
+func (e *ExprRowsetFunction) SetSpan(span Span)      { e.Span = span }
+func (rc *RowsetColumn) SetSpan(span Span)           { rc.Span = span }
 
 func (e ExprStringLiteral) GetSpan() Span        { return e.Span }
 func (e ExprNumberLiteral) GetSpan() Span        { return e.Span }
@@ -758,10 +1261,14 @@ func (e ExprStar) GetSpan() Span                 { return e.Span }
 func (e ExprWithAlias) GetSpan() Span            { return e.Span }
 func (e ExprCompoundIdentifier) GetSpan() Span   { return e.Span }
 func (e ExprBuiltInFunctionName) GetSpan() Span  { return e.Span }
+func (e ExprDatePart) GetSpan() Span             { return e.Span }
 func (si SelectItems) GetSpan() Span             { return si.Span }
 func (w WhereClause) GetSpan() Span              { return w.Span }
 func (h HavingClause) GetSpan() Span             { return h.Span }
+func (i IntoClause) GetSpan() Span               { return i.Span }
 func (gb GroupByClause) GetSpan() Span           { return gb.Span }
+func (e ExprGrouping) GetSpan() Span             { return e.Span }
+func (e ExprGroupingSet) GetSpan() Span          { return e.Span }
 func (ta TableArg) GetSpan() Span                { return ta.Span }
 func (ts TableSource) GetSpan() Span             { return ts.Span }
 func (j Join) GetSpan() Span                     { return j.Span }
@@ -772,14 +1279,33 @@ func (o OrderByArg) GetSpan() Span               { return o.Span }
 func (o OffsetArg) GetSpan() Span                { return o.Span }
 func (f FetchArg) GetSpan() Span                 { return f.Span }
 func (e ExprSubquery) GetSpan() Span             { return e.Span }
+func (e ExprValuesTable) GetSpan() Span          { return e.Span }
 func (e ExprExpressionList) GetSpan() Span       { return e.Span }
 func (e ExprFunction) GetSpan() Span             { return e.Span }
 func (w WindowFrameBound) GetSpan() Span         { return w.Span }
 func (w WindowFrameClause) GetSpan() Span        { return w.Span }
 func (e FunctionOverClause) GetSpan() Span       { return e.Span }
+func (wg WithinGroupClause) GetSpan() Span       { return wg.Span }
 func (e ExprFunctionCall) GetSpan() Span         { return e.Span }
 func (e ExprCast) GetSpan() Span                 { return e.Span }
+func (e ExprConvert) GetSpan() Span              { return e.Span }
+func (e ExprParse) GetSpan() Span                { return e.Span }
+func (e ExprCase) GetSpan() Span                 { return e.Span }
+func (w WhenClause) GetSpan() Span               { return w.Span }
+func (e ElseClause) GetSpan() Span               { return e.Span }
 func (cte *CommonTableExpression) GetSpan() Span { return cte.Span }
+func (e ExprNullLiteral) GetSpan() Span          { return e.Span }
+func (e TableValueConstructor) GetSpan() Span    { return e.Span }
+func (oc OutputClause) GetSpan() Span            { return oc.Span }
+func (e ExprPivot) GetSpan() Span                { return e.Span }
+func (th TableHint) GetSpan() Span               { return th.Span }
+func (oc OptionClause) GetSpan() Span            { return oc.Span }
+func (qh QueryHint) GetSpan() Span               { return qh.Span }
+func (ov OptimizeForVariable) GetSpan() Span     { return ov.Span }
+func (fc ForClause) GetSpan() Span               { return fc.Span }
+func (fo ForOption) GetSpan() Span               { return fo.Span }
+func (e ExprRowsetFunction) GetSpan() Span       { return e.Span }
+func (rc RowsetColumn) GetSpan() Span            { return rc.Span }
 
 type TableSourceType uint8
 
@@ -787,6 +1313,24 @@ const (
 	TSTTable TableSourceType = iota
 	TSTDerived
 	TSTTableValuedFunction
+	TSTPivot
+	TSTUnpivot
+)
+
+type PseudoTable uint8
+
+const (
+	PTNone PseudoTable = iota
+	PTInserted
+	PTDeleted
+)
+
+type GroupingType uint8
+
+const (
+	GTRollup GroupingType = iota
+	GTCube
+	GTGroupingSets
 )
 
 type JoinType uint8
@@ -799,6 +1343,8 @@ const (
 	JTRightOuter
 	JTFull
 	JTFullOuter
+	JTCrossApply
+	JTOuterApply
 )
 
 type OrderByType uint8
@@ -867,6 +1413,9 @@ const (
 	FuncGetdate
 	FuncChecksum
 	FuncNewId
+	FuncGrouping
+	FuncGroupingId
+	FuncSystem
 	FuncUserDefined
 )
 
@@ -38,30 +38,59 @@ func (k *Keyword) SetSpan(span Span)   { k.Span = span }
 func (k Keyword) GetSpan() Span        { return k.Span }
 func (k Keyword) TokenLiteral() string { return k.Type.String() }
 
-type KeywordType uint8
+type KeywordType uint16
 
 const (
 	KAll KeywordType = iota
+	KAbsent
+	KAction
+	KAdd
+	KAfter
 	KAlter
 	KAnd
+	KAnsiDefaults
+	KAnsiNullDfltOff
+	KAnsiNullDfltOn
+	KAnsiNulls
+	KAnsiPadding
+	KAnsiWarnings
 	KAny
+	KApply
+	KArithabort
+	KArithignore
 	KAs
 	KAsc
+	KAuto
 	KAutoincrement
+	KBase64
 	KBegin
 	KBetween
+	KBinary
+	KBreak
+	KBrowse
 	KBy
+	KCaller
 	KCascade
 	KCase
 	KCast
+	KCatch
 	KChar
+	KCheck
+	KClustered
 	KColumn
 	KColumns
 	KCommit
 	KCommited
+	KConcatNullYieldsNull
 	KConstraint
+	KContinue
+	KConvert
 	KCreate
+	KCross
+	KCube
 	KCurrent
+	KCursor
+	KCursorCloseOnCommit
 	KDay
 	KDayofweek
 	KDayofyear
@@ -74,45 +103,76 @@ const (
 	KDistinct
 	KDo
 	KDrop
+	KElements
 	KElse
+	KEncryption
 	KEnd
 	KEngine
 	KExec
 	KExecute
+	KExcept
 	KExists
+	KExplicit
 	KFalse
+	KFast
 	KFetch
 	KFirst
 	KFloat
 	KFloor
+	KFmtonly
 	KFollowing
+	KFor
+	KForce
+	KForceplan
+	KForcescan
+	KForceseek
 	KForeign
 	KFrom
 	KFull
 	KFunction
+	KGo
 	KGroup
+	KGrouping
+	KHash
 	KHaving
+	KHoldlock
 	KHour
 	KHours
 	KIdentity
 	KIf
+	KIgnoreConstraints
+	KIgnoreTriggers
+	KImplicitTransactions
 	KIn
+	KInclude
+	KIncludeNullValues
 	KIncrement
 	KIndex
 	KInner
 	KInsert
+	KInstead
 	KInteger
 	KIntersect
 	KInt
 	KInto
 	KIs
 	KJoin
+	KJson
+	KKeepdefaults
+	KKeepfixed
+	KKeepidentity
 	KKey
 	KLast
 	KLead
 	KLeft
 	KLike
 	KLimit
+	KLog
+	KLoop
+	KMatched
+	KMaxdop
+	KMaxrecursion
+	KMerge
 	KMicrosecond
 	KMicroseconds
 	KMillisecond
@@ -124,94 +184,195 @@ const (
 	KNanoseconds
 	KNchar
 	KNext
+	KNo
+	KNocount
+	KNoexec
+	KNoexpand
+	KNolock
+	KNonclustered
 	KNot
+	KNowait
+	KNull
+	KNumericRoundabort
+	KOf
+	KOff
 	KOffset
 	KOn
 	KOnly
+	KOpenjson
+	KOpenxml
+	KOptimize
+	KOption
 	KOr
 	KOrder
+	KOut
 	KOuter
+	KOutput
 	KOver
+	KOwner
+	KPaglock
+	KParse
+	KParseonly
 	KPartition
 	KPassword
+	KPath
 	KPercent
+	KPersisted
 	KPi
+	KPivot
+	KPlan
 	KPower
 	KPreceding
+	KPrimary
+	KPrint
+	KProc
 	KProcedure
+	KQuotedIdentifier
 	KRadians
+	KRaiserror
 	KRands
+	KRaw
+	KReadcommitted
+	KReadcommittedlock
+	KReadonly
+	KReadpast
+	KReaduncommitted
+	KRecompile
+	KReferences
+	KRemoteProcTransactions
+	KRepeatableread
 	KReturn
 	KReturns
 	KRevoke
 	KRight
 	KRole
 	KRollback
+	KRollup
+	KRoot
 	KRound
 	KRow
 	KRowId
+	KRowlock
 	KRows
+	KSave
+	KSchemabinding
 	KRowNumber
 	KSecond
 	KSelect
+	KSelf
+	KSerializable
 	KSet
+	KSeterror
+	KSets
+	KShowplanAll
+	KShowplanText
+	KShowplanXml
 	KSign
 	KSnapshot
 	KSome
+	KSource
 	KStage
 	KStart
 	KStatistics
+	KType
 	KTable
+	KTablock
+	KTablockx
+	KTarget
 	KTemp
 	KThen
+	KThrow
 	KTies
 	KTop
+	KTran
 	KTransaction
 	KTrigger
 	KTrue
 	KTruncate
+	KTry
+	KTryCast
+	KTryConvert
+	KTryParse
 	KUnbounded
 	KUncommitted
 	KUnion
 	KUnique
+	KUnknown
 	KUnlock
+	KUnpivot
 	KUpdate
+	KUpdlock
 	KUpper
 	KUse
 	KUser
+	KUsing
 	KUuid
 	KValue
 	KValues
+	KView
 	KWeek
 	KWhen
 	KWhere
+	KWhile
 	KWindow
 	KWith
+	KWithin
+	KWithoutArrayWrapper
+	KWork
+	KXactAbort
+	KXlock
+	KXml
+	KXsinil
 	KYear
 )
 
 var Keywords = map[string]KeywordType{
+	"absent":        KAbsent,
+	"action":        KAction,
+	"add":           KAdd,
+	"after":         KAfter,
 	"all":           KAll,
 	"alter":         KAlter,
 	"and":           KAnd,
+	"ansi_defaults": KAnsiDefaults,
+	"ansi_nulls":    KAnsiNulls,
+	"ansi_padding":  KAnsiPadding,
+	"ansi_warnings": KAnsiWarnings,
 	"any":           KAny,
+	"apply":         KApply,
+	"arithabort":    KArithabort,
+	"arithignore":   KArithignore,
 	"as":            KAs,
 	"asc":           KAsc,
+	"auto":          KAuto,
 	"autoincrement": KAutoincrement,
+	"base64":        KBase64,
 	"begin":         KBegin,
 	"between":       KBetween,
+	"binary":        KBinary,
+	"break":         KBreak,
+	"browse":        KBrowse,
 	"by":            KBy,
+	"caller":        KCaller,
 	"cascade":       KCascade,
 	"case":          KCase,
 	"char":          KChar,
+	"check":         KCheck,
+	"clustered":     KClustered,
 	"cast":          KCast,
+	"catch":         KCatch,
 	"column":        KColumn,
 	"columns":       KColumns,
 	"commit":        KCommit,
 	"commited":      KCommited,
 	"constraint":    KConstraint,
+	"continue":      KContinue,
+	"convert":       KConvert,
 	"create":        KCreate,
+	"cross":         KCross,
+	"cube":          KCube,
 	"current":       KCurrent,
+	"cursor":        KCursor,
 	"day":           KDay,
 	"dayofweek":     KDayofweek,
 	"dayofyear":     KDayofyear,
@@ -224,45 +385,72 @@ var Keywords = map[string]KeywordType{
 	"distinct":      KDistinct,
 	"do":            KDo,
 	"drop":          KDrop,
+	"elements":      KElements,
 	"else":          KElse,
+	"encryption":    KEncryption,
 	"end":           KEnd,
 	"engine":        KEngine,
 	"exec":          KExec,
 	"execute":       KExecute,
+	"except":        KExcept,
 	"exists":        KExists,
+	"explicit":      KExplicit,
 	"false":         KFalse,
+	"fast":          KFast,
 	"fetch":         KFetch,
 	"first":         KFirst,
 	"float":         KFloat,
 	"floor":         KFloor,
+	"fmtonly":       KFmtonly,
 	"following":     KFollowing,
+	"for":           KFor,
+	"force":         KForce,
+	"forceplan":     KForceplan,
+	"forcescan":     KForcescan,
+	"forceseek":     KForceseek,
 	"foreign":       KForeign,
 	"from":          KFrom,
 	"full":          KFull,
 	"function":      KFunction,
+	"go":            KGo,
 	"group":         KGroup,
+	"grouping":      KGrouping,
+	"hash":          KHash,
 	"having":        KHaving,
+	"holdlock":      KHoldlock,
 	"hour":          KHour,
 	"hours":         KHours,
 	"identity":      KIdentity,
 	"if":            KIf,
 	"in":            KIn,
+	"include":       KInclude,
 	"increment":     KIncrement,
 	"index":         KIndex,
 	"inner":         KInner,
 	"insert":        KInsert,
+	"instead":       KInstead,
 	"integer":       KInteger,
 	"intersect":     KIntersect,
 	"int":           KInt,
 	"into":          KInto,
 	"is":            KIs,
 	"join":          KJoin,
+	"json":          KJson,
+	"keepdefaults":  KKeepdefaults,
+	"keepfixed":     KKeepfixed,
+	"keepidentity":  KKeepidentity,
 	"key":           KKey,
 	"last":          KLast,
 	"lead":          KLead,
 	"left":          KLeft,
 	"like":          KLike,
 	"limit":         KLimit,
+	"log":           KLog,
+	"loop":          KLoop,
+	"matched":       KMatched,
+	"maxdop":        KMaxdop,
+	"maxrecursion":  KMaxrecursion,
+	"merge":         KMerge,
 	"microsecond":   KMicrosecond,
 	"microseconds":  KMicroseconds,
 	"millisecond":   KMillisecond,
@@ -274,102 +462,236 @@ var Keywords = map[string]KeywordType{
 	"nanoseconds":   KNanoseconds,
 	"nchar":         KNchar,
 	"next":          KNext,
+	"no":            KNo,
+	"nocount":       KNocount,
+	"noexec":        KNoexec,
+	"noexpand":      KNoexpand,
+	"nolock":        KNolock,
+	"nonclustered":  KNonclustered,
 	"not":           KNot,
+	"nowait":        KNowait,
+	"null":          KNull,
+	"of":            KOf,
+	"off":           KOff,
 	"offset":        KOffset,
 	"on":            KOn,
 	"only":          KOnly,
+	"openjson":      KOpenjson,
+	"openxml":       KOpenxml,
+	"optimize":      KOptimize,
+	"option":        KOption,
 	"or":            KOr,
 	"order":         KOrder,
+	"out":           KOut,
 	"outer":         KOuter,
+	"output":        KOutput,
 	"over":          KOver,
+	"owner":         KOwner,
+	"paglock":       KPaglock,
+	"parse":         KParse,
+	"parseonly":     KParseonly,
 	"partition":     KPartition,
 	"password":      KPassword,
+	"path":          KPath,
 	"percent":       KPercent,
+	"persisted":     KPersisted,
 	"pi":            KPi,
+	"pivot":         KPivot,
+	"plan":          KPlan,
 	"power":         KPower,
 	"preceding":     KPreceding,
+	"primary":       KPrimary,
+	"print":         KPrint,
+	"proc":          KProc,
 	"procedure":     KProcedure,
 	"radians":       KRadians,
+	"raiserror":     KRaiserror,
 	"rands":         KRands,
+	"raw":           KRaw,
+	"readcommitted": KReadcommitted,
+	"readonly":      KReadonly,
+	"readpast":      KReadpast,
+	"recompile":     KRecompile,
+	"references":    KReferences,
 	"return":        KReturn,
 	"returns":       KReturns,
 	"revoke":        KRevoke,
 	"right":         KRight,
 	"role":          KRole,
 	"rollback":      KRollback,
+	"rollup":        KRollup,
+	"root":          KRoot,
 	"round":         KRound,
 	"row":           KRow,
 	"rowid":         KRowId,
+	"rowlock":       KRowlock,
 	"rows":          KRows,
+	"save":          KSave,
+	"schemabinding": KSchemabinding,
 	"row_number":    KRowNumber,
 	"second":        KSecond,
 	"select":        KSelect,
+	"self":          KSelf,
+	"serializable":  KSerializable,
 	"set":           KSet,
+	"seterror":      KSeterror,
+	"sets":          KSets,
+	"showplan_all":  KShowplanAll,
+	"showplan_text": KShowplanText,
+	"showplan_xml":  KShowplanXml,
 	"sign":          KSign,
 	"snapshot":      KSnapshot,
 	"some":          KSome,
+	"source":        KSource,
 	"stage":         KStage,
 	"start":         KStart,
 	"tstatistics":   KStatistics,
+	"type":          KType,
 	"table":         KTable,
+	"tablock":       KTablock,
+	"tablockx":      KTablockx,
+	"target":        KTarget,
 	"temp":          KTemp,
 	"then":          KThen,
+	"throw":         KThrow,
 	"ties":          KTies,
 	"top":           KTop,
+	"tran":          KTran,
 	"transaction":   KTransaction,
 	"trigger":       KTrigger,
 	"true":          KTrue,
 	"truncate":      KTruncate,
+	"try":           KTry,
+	"try_cast":      KTryCast,
+	"try_convert":   KTryConvert,
+	"try_parse":     KTryParse,
 	"unbounded":     KUnbounded,
 	"uncommitted":   KUncommitted,
 	"union":         KUnion,
 	"unique":        KUnique,
+	"unknown":       KUnknown,
 	"unlock":        KUnlock,
+	"unpivot":       KUnpivot,
 	"update":        KUpdate,
+	"updlock":       KUpdlock,
 	"upper":         KUpper,
 	"use":           KUse,
 	"user":          KUser,
+	"using":         KUsing,
 	"uuid":          KUuid,
 	"value":         KValue,
 	"values":        KValues,
+	"view":          KView,
 	"week":          KWeek,
 	"when":          KWhen,
 	"where":         KWhere,
+	"while":         KWhile,
 	"window":        KWindow,
 	"with":          KWith,
+	"within":        KWithin,
+	"work":          KWork,
+	"xact_abort":    KXactAbort,
+	"xlock":         KXlock,
+	"xml":           KXml,
+	"xsinil":        KXsinil,
 	"year":          KYear,
+
+	// for json options
+	"include_null_values":   KIncludeNullValues,
+	"without_array_wrapper": KWithoutArrayWrapper,
+
+	// table hints
+	"ignore_constraints": KIgnoreConstraints,
+	"ignore_triggers":    KIgnoreTriggers,
+	"readcommittedlock":  KReadcommittedlock,
+	"readuncommitted":    KReaduncommitted,
+	"repeatableread":     KRepeatableread,
+
+	// set options
+	"ansi_null_dflt_off":       KAnsiNullDfltOff,
+	"ansi_null_dflt_on":        KAnsiNullDfltOn,
+	"concat_null_yields_null":  KConcatNullYieldsNull,
+	"cursor_close_on_commit":   KCursorCloseOnCommit,
+	"implicit_transactions":    KImplicitTransactions,
+	"numeric_roundabort":       KNumericRoundabort,
+	"quoted_identifier":        KQuotedIdentifier,
+	"remote_proc_transactions": KRemoteProcTransactions,
 }
 
 func (k KeywordType) String() string {
 	switch k {
 	case KAll:
 		return "All"
+	case KAbsent:
+		return "Absent"
+	case KAction:
+		return "Action"
+	case KAdd:
+		return "Add"
+	case KAfter:
+		return "After"
 	case KAlter:
 		return "Alter"
 	case KAnd:
 		return "And"
+	case KAnsiDefaults:
+		return "ANSI_DEFAULTS"
+	case KAnsiNullDfltOff:
+		return "ANSI_NULL_DFLT_OFF"
+	case KAnsiNullDfltOn:
+		return "ANSI_NULL_DFLT_ON"
+	case KAnsiNulls:
+		return "ANSI_NULLS"
+	case KAnsiPadding:
+		return "ANSI_PADDING"
+	case KAnsiWarnings:
+		return "ANSI_WARNINGS"
 	case KAny:
 		return "Any"
+	case KApply:
+		return "Apply"
+	case KArithabort:
+		return "ARITHABORT"
+	case KArithignore:
+		return "ARITHIGNORE"
 	case KAs:
 		return "As"
 	case KAsc:
 		return "Asc"
+	case KAuto:
+		return "Auto"
 	case KAutoincrement:
 		return "Autoincrement"
+	case KBase64:
+		return "Base64"
 	case KBegin:
 		return "Begin"
 	case KBetween:
 		return "Between"
+	case KBinary:
+		return "Binary"
+	case KBreak:
+		return "Break"
+	case KBrowse:
+		return "Browse"
 	case KBy:
 		return "By"
+	case KCaller:
+		return "Caller"
 	case KCascade:
 		return "Cascade"
 	case KCase:
 		return "Case"
 	case KCast:
 		return "Cast"
+	case KCatch:
+		return "Catch"
 	case KChar:
 		return "Char"
+	case KCheck:
+		return "Check"
+	case KClustered:
+		return "Clustered"
 	case KColumn:
 		return "Column"
 	case KColumns:
@@ -378,12 +700,26 @@ func (k KeywordType) String() string {
 		return "Commit"
 	case KCommited:
 		return "Commited"
+	case KConcatNullYieldsNull:
+		return "CONCAT_NULL_YIELDS_NULL"
 	case KConstraint:
 		return "Constraint"
+	case KContinue:
+		return "Continue"
+	case KConvert:
+		return "Convert"
 	case KCreate:
 		return "Create"
+	case KCross:
+		return "Cross"
+	case KCube:
+		return "Cube"
 	case KCurrent:
 		return "Current"
+	case KCursor:
+		return "Cursor"
+	case KCursorCloseOnCommit:
+		return "CURSOR_CLOSE_ON_COMMIT"
 	case KDay:
 		return "Day"
 	case KDayofweek:
@@ -408,8 +744,12 @@ func (k KeywordType) String() string {
 		return "Do"
 	case KDrop:
 		return "Drop"
+	case KElements:
+		return "Elements"
 	case KElse:
 		return "Else"
+	case KEncryption:
+		return "Encryption"
 	case KEnd:
 		return "End"
 	case KEngine:
@@ -418,10 +758,16 @@ func (k KeywordType) String() string {
 		return "Exec"
 	case KExecute:
 		return "Execute"
+	case KExcept:
+		return "Except"
 	case KExists:
 		return "Exists"
+	case KExplicit:
+		return "Explicit"
 	case KFalse:
 		return "False"
+	case KFast:
+		return "Fast"
 	case KFetch:
 		return "Fetch"
 	case KFirst:
@@ -430,8 +776,20 @@ func (k KeywordType) String() string {
 		return "Float"
 	case KFloor:
 		return "Floor"
+	case KFmtonly:
+		return "FMTONLY"
 	case KFollowing:
 		return "Following"
+	case KFor:
+		return "For"
+	case KForce:
+		return "Force"
+	case KForceplan:
+		return "FORCEPLAN"
+	case KForcescan:
+		return "Forcescan"
+	case KForceseek:
+		return "Forceseek"
 	case KForeign:
 		return "Foreign"
 	case KFrom:
@@ -440,10 +798,18 @@ func (k KeywordType) String() string {
 		return "Full"
 	case KFunction:
 		return "Function"
+	case KGo:
+		return "Go"
 	case KGroup:
 		return "Group"
+	case KGrouping:
+		return "Grouping"
+	case KHash:
+		return "Hash"
 	case KHaving:
 		return "Having"
+	case KHoldlock:
+		return "Holdlock"
 	case KHour:
 		return "Hour"
 	case KHours:
@@ -452,8 +818,18 @@ func (k KeywordType) String() string {
 		return "Identity"
 	case KIf:
 		return "If"
+	case KIgnoreConstraints:
+		return "IGNORE_CONSTRAINTS"
+	case KIgnoreTriggers:
+		return "IGNORE_TRIGGERS"
+	case KImplicitTransactions:
+		return "IMPLICIT_TRANSACTIONS"
 	case KIn:
 		return "In"
+	case KInclude:
+		return "Include"
+	case KIncludeNullValues:
+		return "INCLUDE_NULL_VALUES"
 	case KIncrement:
 		return "Increment"
 	case KIndex:
@@ -462,6 +838,8 @@ func (k KeywordType) String() string {
 		return "Inner"
 	case KInsert:
 		return "Insert"
+	case KInstead:
+		return "Instead"
 	case KInteger:
 		return "Integer"
 	case KIntersect:
@@ -474,6 +852,14 @@ func (k KeywordType) String() string {
 		return "Is"
 	case KJoin:
 		return "Join"
+	case KJson:
+		return "Json"
+	case KKeepdefaults:
+		return "Keepdefaults"
+	case KKeepfixed:
+		return "Keepfixed"
+	case KKeepidentity:
+		return "Keepidentity"
 	case KKey:
 		return "Key"
 	case KLast:
@@ -486,6 +872,18 @@ func (k KeywordType) String() string {
 		return "Like"
 	case KLimit:
 		return "Limit"
+	case KLog:
+		return "Log"
+	case KLoop:
+		return "Loop"
+	case KMatched:
+		return "Matched"
+	case KMaxdop:
+		return "Maxdop"
+	case KMaxrecursion:
+		return "Maxrecursion"
+	case KMerge:
+		return "Merge"
 	case KMicrosecond:
 		return "Microsecond"
 	case KMicroseconds:
@@ -508,40 +906,120 @@ func (k KeywordType) String() string {
 		return "Nchar"
 	case KNext:
 		return "Next"
+	case KNo:
+		return "No"
+	case KNocount:
+		return "Nocount"
+	case KNoexec:
+		return "NOEXEC"
+	case KNoexpand:
+		return "Noexpand"
+	case KNolock:
+		return "Nolock"
+	case KNonclustered:
+		return "Nonclustered"
 	case KNot:
 		return "Not"
+	case KNowait:
+		return "Nowait"
+	case KNull:
+		return "Null"
+	case KNumericRoundabort:
+		return "NUMERIC_ROUNDABORT"
+	case KOf:
+		return "Of"
+	case KOff:
+		return "Off"
 	case KOffset:
 		return "Offset"
 	case KOn:
 		return "On"
 	case KOnly:
 		return "Only"
+	case KOpenjson:
+		return "OPENJSON"
+	case KOpenxml:
+		return "OPENXML"
+	case KOptimize:
+		return "Optimize"
+	case KOption:
+		return "Option"
 	case KOr:
 		return "Or"
 	case KOrder:
 		return "Order"
+	case KOut:
+		return "Out"
 	case KOuter:
 		return "Outer"
+	case KOutput:
+		return "Output"
 	case KOver:
 		return "Over"
+	case KOwner:
+		return "Owner"
+	case KPaglock:
+		return "Paglock"
+	case KParse:
+		return "Parse"
+	case KParseonly:
+		return "PARSEONLY"
 	case KPartition:
 		return "Partition"
 	case KPassword:
 		return "Password"
+	case KPath:
+		return "Path"
 	case KPercent:
 		return "Percent"
+	case KPersisted:
+		return "Persisted"
 	case KPi:
 		return "Pi"
+	case KPivot:
+		return "Pivot"
+	case KPlan:
+		return "Plan"
 	case KPower:
 		return "Power"
 	case KPreceding:
 		return "Preceding"
+	case KPrimary:
+		return "Primary"
+	case KPrint:
+		return "Print"
+	case KProc:
+		return "Proc"
 	case KProcedure:
 		return "Procedure"
+	case KQuotedIdentifier:
+		return "QUOTED_IDENTIFIER"
 	case KRadians:
 		return "Radians"
+	case KRaiserror:
+		return "Raiserror"
 	case KRands:
 		return "Rands"
+	case KRaw:
+		return "Raw"
+	case KReadcommitted:
+		return "Readcommitted"
+	case KReadcommittedlock:
+		return "Readcommittedlock"
+	case KReadonly:
+		return "Readonly"
+	case KReadpast:
+		return "Readpast"
+	case KReaduncommitted:
+		return "Readuncommitted"
+	case KRecompile:
+		return "Recompile"
+	case KReferences:
+		return "References"
+	case KRemoteProcTransactions:
+		return "REMOTE_PROC_TRANSACTIONS"
+	case KRepeatableread:
+		return "Repeatableread"
 	case KReturn:
 		return "Return"
 	case KReturns:
@@ -554,44 +1032,82 @@ func (k KeywordType) String() string {
 		return "Role"
 	case KRollback:
 		return "Rollback"
+	case KRollup:
+		return "Rollup"
+	case KRoot:
+		return "Root"
 	case KRound:
 		return "Round"
 	case KRow:
 		return "Row"
 	case KRowId:
 		return "Rowid"
+	case KRowlock:
+		return "Rowlock"
 	case KRows:
 		return "Rows"
+	case KSave:
+		return "Save"
+	case KSchemabinding:
+		return "Schemabinding"
 	case KRowNumber:
 		return "RowNumber"
 	case KSecond:
 		return "Second"
 	case KSelect:
 		return "Select"
+	case KSelf:
+		return "Self"
+	case KSerializable:
+		return "Serializable"
 	case KSet:
 		return "Set"
+	case KSeterror:
+		return "Seterror"
+	case KSets:
+		return "Sets"
+	case KShowplanAll:
+		return "SHOWPLAN_ALL"
+	case KShowplanText:
+		return "SHOWPLAN_TEXT"
+	case KShowplanXml:
+		return "SHOWPLAN_XML"
 	case KSign:
 		return "Sign"
 	case KSnapshot:
 		return "Snapshot"
 	case KSome:
 		return "Some"
+	case KSource:
+		return "Source"
 	case KStage:
 		return "Stage"
 	case KStart:
 		return "Start"
 	case KStatistics:
 		return "Statistics"
+	case KType:
+		return "Type"
 	case KTable:
 		return "Table"
+	case KTablock:
+		return "Tablock"
+	case KTablockx:
+		return "Tablockx"
+	case KTarget:
+		return "Target"
 	case KTemp:
 		return "Temp"
 	case KThen:
 		return "Then"
+	case KThrow:
+		return "Throw"
 	case KTies:
 		return "Ties"
 	case KTop:
 		return "Top"
+	case KTran:
+		return "Tran"
 	case KTransaction:
 		return "Transaction"
 	case KTrigger:
@@ -600,6 +1116,14 @@ func (k KeywordType) String() string {
 		return "True"
 	case KTruncate:
 		return "Truncate"
+	case KTry:
+		return "Try"
+	case KTryCast:
+		return "TRY_CAST"
+	case KTryConvert:
+		return "TRY_CONVERT"
+	case KTryParse:
+		return "TRY_PARSE"
 	case KUnbounded:
 		return "Unbounded"
 	case KUncommitted:
@@ -608,32 +1132,58 @@ func (k KeywordType) String() string {
 		return "Union"
 	case KUnique:
 		return "Unique"
+	case KUnknown:
+		return "Unknown"
 	case KUnlock:
 		return "Unlock"
+	case KUnpivot:
+		return "Unpivot"
 	case KUpdate:
 		return "Update"
+	case KUpdlock:
+		return "Updlock"
 	case KUpper:
 		return "Upper"
 	case KUse:
 		return "Use"
 	case KUser:
 		return "User"
+	case KUsing:
+		return "Using"
 	case KUuid:
 		return "Uuid"
 	case KValue:
 		return "Value"
 	case KValues:
 		return "Values"
+	case KView:
+		return "View"
 	case KWeek:
 		return "Week"
 	case KWhen:
 		return "When"
 	case KWhere:
 		return "Where"
+	case KWhile:
+		return "While"
 	case KWindow:
 		return "Window"
 	case KWith:
 		return "With"
+	case KWithin:
+		return "Within"
+	case KWithoutArrayWrapper:
+		return "WITHOUT_ARRAY_WRAPPER"
+	case KWork:
+		return "Work"
+	case KXactAbort:
+		return "XACT_ABORT"
+	case KXlock:
+		return "Xlock"
+	case KXml:
+		return "Xml"
+	case KXsinil:
+		return "Xsinil"
 	case KYear:
 		return "Year"
 	}
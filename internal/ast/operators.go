@@ -109,6 +109,13 @@ type ExprAnyLogicalOperator struct {
 	Subquery           *ExprSubquery
 }
 
+type ExprAssignmentOperator struct {
+	Span
+	Left             Expression
+	Right            Expression
+	Operator         AssignmentOperatorType
+}
+
 func (e ExprUnaryOperator) expressionNode()             {}
 func (e ExprComparisonOperator) expressionNode()        {}
 func (e ExprArithmeticOperator) expressionNode()        {}
@@ -123,6 +130,7 @@ func (e ExprNotLogicalOperator) expressionNode()        {}
 func (e ExprOrLogicalOperator) expressionNode()         {}
 func (e ExprSomeLogicalOperator) expressionNode()       {}
 func (e ExprAnyLogicalOperator) expressionNode()        {}
+func (e ExprAssignmentOperator) expressionNode()        {}
 
 func (e ExprUnaryOperator) TokenLiteral() string {
 	var str strings.Builder
@@ -264,6 +272,13 @@ func (e ExprAnyLogicalOperator) TokenLiteral() string {
 	str.WriteString(e.Subquery.TokenLiteral())
 	return str.String()
 }
+func (e ExprAssignmentOperator) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(e.Left.TokenLiteral())
+	str.WriteString(fmt.Sprintf(" %s ", e.Operator.TokenLiteral()))
+	str.WriteString(e.Right.TokenLiteral())
+	return str.String()
+}
 func (o ComparisonOperatorType) TokenLiteral() string {
 	var str string
 	switch o {
@@ -284,6 +299,30 @@ func (o ComparisonOperatorType) TokenLiteral() string {
 	}
 	return str
 }
+func (o AssignmentOperatorType) TokenLiteral() string {
+	var str string
+	switch o {
+	case AssignmentOpEqual:
+		str = "="
+	case AssignmentOpPlusEqual:
+		str = "+="
+	case AssignmentOpMinusEqual:
+		str = "-="
+	case AssignmentOpMultiplyEqual:
+		str = "*="
+	case AssignmentOpDivideEqual:
+		str = "/="
+	case AssignmentOpModEqual:
+		str = "%="
+	case AssignmentOpAndEqual:
+		str = "&="
+	case AssignmentOpOrEqual:
+		str = "|="
+	case AssignmentOpXorEqual:
+		str = "^="
+	}
+	return str
+}
 
 func (e *ExprUnaryOperator) SetSpan(span Span)             { e.Span = span }
 func (e *ExprComparisonOperator) SetSpan(span Span)        { e.Span = span }
@@ -299,6 +338,7 @@ func (e *ExprNotLogicalOperator) SetSpan(span Span)        { e.Span = span }
 func (e *ExprOrLogicalOperator) SetSpan(span Span)         { e.Span = span }
 func (e *ExprSomeLogicalOperator) SetSpan(span Span)       { e.Span = span }
 func (e *ExprAnyLogicalOperator) SetSpan(span Span)        { e.Span = span }
+func (e *ExprAssignmentOperator) SetSpan(span Span)        { e.Span = span }
 
 func (e ExprUnaryOperator) GetSpan() Span             { return e.Span }
 func (e ExprComparisonOperator) GetSpan() Span        { return e.Span }
@@ -314,10 +354,12 @@ func (e ExprNotLogicalOperator) GetSpan() Span        { return e.Span }
 func (e ExprOrLogicalOperator) GetSpan() Span         { return e.Span }
 func (e ExprSomeLogicalOperator) GetSpan() Span       { return e.Span }
 func (e ExprAnyLogicalOperator) GetSpan() Span        { return e.Span }
+func (e ExprAssignmentOperator) GetSpan() Span        { return e.Span }
 
 type UnaryOperatorType uint8
 type ComparisonOperatorType uint8
 type ArithmeticOperatorType uint8
+type AssignmentOperatorType uint8
 
 const (
 	ComparisonOpEqual ComparisonOperatorType = iota
@@ -339,3 +381,14 @@ const (
 	UnaryOpPlus UnaryOperatorType = iota
 	UnaryOpMinus
 )
+const (
+	AssignmentOpEqual AssignmentOperatorType = iota
+	AssignmentOpPlusEqual
+	AssignmentOpMinusEqual
+	AssignmentOpMultiplyEqual
+	AssignmentOpDivideEqual
+	AssignmentOpModEqual
+	AssignmentOpAndEqual
+	AssignmentOpOrEqual
+	AssignmentOpXorEqual
+)
@@ -5,18 +5,79 @@ import (
 	"strings"
 )
 
-type DeclareStatement struct{}
-type ExecuteStatement struct{}
-type SetLocalVariableStatement struct{}
-type InsertStatement struct{}
-type UpdateStatement struct{}
-type DeleteStatement struct{}
+func cteToString(withKeyword *Keyword, ctes *[]CommonTableExpression) string {
+	if ctes == nil {
+		return ""
+	}
+
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s ", withKeyword.TokenLiteral()))
+	cteStrings := []string{}
+
+	for _, cte := range *ctes {
+		cteStrings = append(cteStrings, cte.TokenLiteral())
+	}
+
+	str.WriteString(strings.Join(cteStrings, ", "))
+	return str.String()
+}
+
+func transactionToString(keyword Keyword, transactionKeyword *Keyword, name Expression) string {
+	var str strings.Builder
+	str.WriteString(keyword.TokenLiteral())
+	if transactionKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", transactionKeyword.TokenLiteral()))
+	}
+	if name != nil {
+		str.WriteString(fmt.Sprintf(" %s", name.TokenLiteral()))
+	}
+
+	return str.String()
+}
+
+type DeclareStatement struct {
+	Span
+	DeclareKeyword Keyword
+	Variables      []VariableDeclaration
+	TableVariable  *TableVariableDeclaration
+	Cursor         *CursorDeclaration
+}
+
+type VariableDeclaration struct {
+	Span
+	Name      ExprLocalVariable
+	AsKeyword *Keyword
+	DataType  *DataType
+	Value     Expression
+}
+
+type TableVariableDeclaration struct {
+	Span
+	Name         ExprLocalVariable
+	AsKeyword    *Keyword
+	TableKeyword Keyword
+	Definitions  []TableElement
+}
+
+type CursorDeclaration struct {
+	Span
+	Name          Expression
+	CursorKeyword Keyword
+	ForKeyword    Keyword
+	Query         QueryExpression
+}
+
+type SetLocalVariableStatement struct {
+	Span
+	SetKeyword Keyword
+	Assignment *ExprAssignmentOperator
+}
 
 type SelectStatement struct {
 	Span
 	WithKeyword *Keyword
 	CTE         *[]CommonTableExpression
-	SelectBody  *SelectBody
+	Query       QueryExpression
 }
 
 type SelectBody struct {
@@ -26,34 +87,350 @@ type SelectBody struct {
 	AllKeyword      *Keyword
 	Top             *TopArg
 	SelectItems     SelectItems
+	IntoClause      *IntoClause
 	Table           *TableArg
 	WhereClause     *WhereClause
 	HavingClause    *HavingClause
 	GroupByClause   *GroupByClause
 	OrderByClause   *OrderByClause
+	ForClause       *ForClause
+	OptionClause    *OptionClause
 }
 
-func (ds DeclareStatement) statementNode() {}
-func (ss SelectStatement) statementNode()  {}
-func (sb SelectBody) statementNode()       {}
+type SetOperation struct {
+	Span
+	Left             QueryExpression
+	OperatorKeywords []Keyword
+	Operator         SetOperatorType
+	Right            QueryExpression
+	OrderByClause    *OrderByClause
+	ForClause        *ForClause
+	OptionClause     *OptionClause
+}
 
-func (ds DeclareStatement) TokenLiteral() string {
-	return ""
+type ParenthesizedQuery struct {
+	Span
+	Query         QueryExpression
+	OrderByClause *OrderByClause
+	ForClause     *ForClause
+	OptionClause  *OptionClause
 }
-func (ss SelectStatement) TokenLiteral() string {
+
+type InsertStatement struct {
+	Span
+	WithKeyword          *Keyword
+	CTE                  *[]CommonTableExpression
+	InsertKeyword        Keyword
+	Top                  *TopArg
+	IntoKeyword          *Keyword
+	Target               Expression
+	Columns              *ExprExpressionList
+	OutputClause         *OutputClause
+	Values               *TableValueConstructor
+	Query                QueryExpression
+	Execute              *ExecuteStatement
+	DefaultValuesKeyword *[2]Keyword
+}
+
+type ExecuteStatement struct {
+	Span
+	ExecKeyword  Keyword
+	ReturnStatus *ExprLocalVariable
+	Name         Expression
+	Arguments    []ExecuteArgument
+	// set instead of Name for EXEC (@sql)
+	Command Expression
+}
+
+type ExecuteArgument struct {
+	Span
+	Name           *ExprLocalVariable
+	Value          Expression
+	DefaultKeyword *Keyword
+	OutputKeyword  *Keyword
+}
+
+type UpdateStatement struct {
+	Span
+	WithKeyword   *Keyword
+	CTE           *[]CommonTableExpression
+	UpdateKeyword Keyword
+	Top           *TopArg
+	Target        Expression
+	SetKeyword    Keyword
+	Assignments   []*ExprAssignmentOperator
+	OutputClause  *OutputClause
+	Table         *TableArg
+	WhereClause   *WhereClause
+}
+
+type DeleteStatement struct {
+	Span
+	WithKeyword   *Keyword
+	CTE           *[]CommonTableExpression
+	DeleteKeyword Keyword
+	Top           *TopArg
+	FromKeyword   *Keyword
+	Target        Expression
+	OutputClause  *OutputClause
+	Table         *TableArg
+	WhereClause   *WhereClause
+}
+
+type MergeStatement struct {
+	Span
+	WithKeyword  *Keyword
+	CTE          *[]CommonTableExpression
+	MergeKeyword Keyword
+	Top          *TopArg
+	IntoKeyword  *Keyword
+	Target       Expression
+	UsingKeyword Keyword
+	Source       *TableSource
+	OnKeyword    Keyword
+	Condition    Expression
+	WhenClauses  []MergeWhenClause
+	OutputClause *OutputClause
+}
+
+// WHEN [NOT] MATCHED [BY TARGET | BY SOURCE] [AND condition] THEN action
+type MergeWhenClause struct {
+	Span
+	WhenKeywords []Keyword
+	Type         MergeWhenType
+	AndKeyword   *Keyword
+	Condition    Expression
+	ThenKeyword  Keyword
+	Action       MergeAction
+}
+
+type MergeUpdateAction struct {
+	Span
+	UpdateKeyword Keyword
+	SetKeyword    Keyword
+	Assignments   []*ExprAssignmentOperator
+}
+
+type MergeInsertAction struct {
+	Span
+	InsertKeyword        Keyword
+	Columns              *ExprExpressionList
+	Values               *TableValueConstructor
+	DefaultValuesKeyword *[2]Keyword
+}
+
+type MergeDeleteAction struct {
+	Span
+	DeleteKeyword Keyword
+}
+
+type BlockStatement struct {
+	Span
+	BeginKeyword Keyword
+	Statements   []Statement
+	EndKeyword   Keyword
+}
+
+type IfStatement struct {
+	Span
+	IfKeyword   Keyword
+	Condition   Expression
+	Then        Statement
+	ElseKeyword *Keyword
+	Else        Statement
+}
+
+type WhileStatement struct {
+	Span
+	WhileKeyword Keyword
+	Condition    Expression
+	Body         Statement
+}
+
+type BreakStatement struct {
+	Span
+	BreakKeyword Keyword
+}
+
+type ContinueStatement struct {
+	Span
+	ContinueKeyword Keyword
+}
+
+type ReturnStatement struct {
+	Span
+	ReturnKeyword Keyword
+	Value         Expression
+}
+
+type TryCatchStatement struct {
+	Span
+	BeginTryKeywords   [2]Keyword
+	TryStatements      []Statement
+	EndTryKeywords     [2]Keyword
+	BeginCatchKeywords [2]Keyword
+	CatchStatements    []Statement
+	EndCatchKeywords   [2]Keyword
+}
+
+// arguments are nil when rethrowing inside a catch block
+type ThrowStatement struct {
+	Span
+	ThrowKeyword Keyword
+	ErrorNumber  Expression
+	Message      Expression
+	State        Expression
+}
+
+type RaiserrorStatement struct {
+	Span
+	RaiserrorKeyword Keyword
+	Arguments        []Expression
+	WithKeyword      *Keyword
+	Options          []Keyword
+}
+
+type PrintStatement struct {
+	Span
+	PrintKeyword Keyword
+	Value        Expression
+}
+
+type BeginTransactionStatement struct {
+	Span
+	BeginKeyword       Keyword
+	TransactionKeyword Keyword
+	Name               Expression
+}
+
+type CommitTransactionStatement struct {
+	Span
+	CommitKeyword      Keyword
+	TransactionKeyword *Keyword
+	Name               Expression
+}
+
+type RollbackTransactionStatement struct {
+	Span
+	RollbackKeyword    Keyword
+	TransactionKeyword *Keyword
+	Name               Expression
+}
+
+type SaveTransactionStatement struct {
+	Span
+	SaveKeyword        Keyword
+	TransactionKeyword Keyword
+	Name               Expression
+}
+
+// SET XACT_ABORT ON, SET NOCOUNT OFF
+type SetOptionStatement struct {
+	Span
+	SetKeyword Keyword
+	Options    []Keyword
+	Value      Keyword
+}
+
+func (ds DeclareStatement) statementNode()             {}
+func (sv SetLocalVariableStatement) statementNode()    {}
+func (ss SelectStatement) statementNode()              {}
+func (sb SelectBody) statementNode()                   {}
+func (is InsertStatement) statementNode()              {}
+func (es ExecuteStatement) statementNode()             {}
+func (us UpdateStatement) statementNode()              {}
+func (del DeleteStatement) statementNode()             {}
+func (ms MergeStatement) statementNode()               {}
+func (blk BlockStatement) statementNode()              {}
+func (ifs IfStatement) statementNode()                 {}
+func (ws WhileStatement) statementNode()               {}
+func (bs BreakStatement) statementNode()               {}
+func (cs ContinueStatement) statementNode()            {}
+func (rs ReturnStatement) statementNode()              {}
+func (tc TryCatchStatement) statementNode()            {}
+func (ts ThrowStatement) statementNode()               {}
+func (ra RaiserrorStatement) statementNode()           {}
+func (ps PrintStatement) statementNode()               {}
+func (bt BeginTransactionStatement) statementNode()    {}
+func (ct CommitTransactionStatement) statementNode()   {}
+func (rt RollbackTransactionStatement) statementNode() {}
+func (st SaveTransactionStatement) statementNode()     {}
+func (sop SetOptionStatement) statementNode()          {}
+
+func (sb *SelectBody) queryExpressionNode()         {}
+func (so *SetOperation) queryExpressionNode()       {}
+func (pq *ParenthesizedQuery) queryExpressionNode() {}
+
+func (mu *MergeUpdateAction) mergeActionNode() {}
+func (mi *MergeInsertAction) mergeActionNode() {}
+func (md *MergeDeleteAction) mergeActionNode() {}
+
+func (ds DeclareStatement) TokenLiteral() string {
 	var str strings.Builder
-	if ss.CTE != nil {
-		str.WriteString(fmt.Sprintf("%s ", ss.WithKeyword.TokenLiteral()))
-		ctes := []string{}
+	str.WriteString(ds.DeclareKeyword.TokenLiteral())
+
+	if ds.TableVariable != nil {
+		str.WriteString(fmt.Sprintf(" %s", ds.TableVariable.TokenLiteral()))
+	} else if ds.Cursor != nil {
+		str.WriteString(fmt.Sprintf(" %s", ds.Cursor.TokenLiteral()))
+	}
 
-		for _, cte := range *ss.CTE {
-			ctes = append(ctes, cte.TokenLiteral())
+	for i, v := range ds.Variables {
+		if i > 0 {
+			str.WriteString(",")
 		}
+		str.WriteString(fmt.Sprintf(" %s", v.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (vd VariableDeclaration) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(vd.Name.TokenLiteral())
 
-		str.WriteString(strings.Join(ctes, ", "))
+	if vd.AsKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", vd.AsKeyword.TokenLiteral()))
 	}
+
+	str.WriteString(fmt.Sprintf(" %s", vd.DataType.TokenLiteral()))
+
+	if vd.Value != nil {
+		str.WriteString(fmt.Sprintf(" = %s", vd.Value.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (tv TableVariableDeclaration) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(tv.Name.TokenLiteral())
+
+	if tv.AsKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", tv.AsKeyword.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s (%s)",
+		tv.TableKeyword.TokenLiteral(),
+		tableElementsToString(tv.Definitions),
+	))
+
+	return str.String()
+}
+func (cd CursorDeclaration) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s %s",
+		cd.Name.TokenLiteral(),
+		cd.CursorKeyword.TokenLiteral(),
+		cd.ForKeyword.TokenLiteral(),
+		cd.Query.TokenLiteral(),
+	)
+}
+func (sv SetLocalVariableStatement) TokenLiteral() string {
+	return fmt.Sprintf("%s %s", sv.SetKeyword.TokenLiteral(), sv.Assignment.TokenLiteral())
+}
+func (ss SelectStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(cteToString(ss.WithKeyword, ss.CTE))
 	str.WriteString(" ")
-	str.WriteString(ss.SelectBody.TokenLiteral())
+	str.WriteString(ss.Query.TokenLiteral())
 	return str.String()
 }
 func (sb SelectBody) TokenLiteral() string {
@@ -73,6 +450,10 @@ func (sb SelectBody) TokenLiteral() string {
 
 	str.WriteString(expressionListToString(sb.SelectItems.Items, ", "))
 
+	if sb.IntoClause != nil {
+		str.WriteString(sb.IntoClause.TokenLiteral())
+	}
+
 	if sb.Table != nil {
 		str.WriteString(sb.Table.TokenLiteral())
 	}
@@ -91,12 +472,469 @@ func (sb SelectBody) TokenLiteral() string {
 	if sb.OrderByClause != nil {
 		str.WriteString(sb.OrderByClause.TokenLiteral())
 	}
+	if sb.ForClause != nil {
+		str.WriteString(sb.ForClause.TokenLiteral())
+	}
+	if sb.OptionClause != nil {
+		str.WriteString(sb.OptionClause.TokenLiteral())
+	}
+
+	return str.String()
+}
+func (so SetOperation) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(so.Left.TokenLiteral())
+	for _, k := range so.OperatorKeywords {
+		str.WriteString(fmt.Sprintf(" %s", k.TokenLiteral()))
+	}
+	str.WriteString(" ")
+	str.WriteString(so.Right.TokenLiteral())
+
+	if so.OrderByClause != nil {
+		str.WriteString(so.OrderByClause.TokenLiteral())
+	}
+	if so.ForClause != nil {
+		str.WriteString(so.ForClause.TokenLiteral())
+	}
+	if so.OptionClause != nil {
+		str.WriteString(so.OptionClause.TokenLiteral())
+	}
+
+	return str.String()
+}
+func (pq ParenthesizedQuery) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("(%s)", pq.Query.TokenLiteral()))
+
+	if pq.OrderByClause != nil {
+		str.WriteString(pq.OrderByClause.TokenLiteral())
+	}
+	if pq.ForClause != nil {
+		str.WriteString(pq.ForClause.TokenLiteral())
+	}
+	if pq.OptionClause != nil {
+		str.WriteString(pq.OptionClause.TokenLiteral())
+	}
+
+	return str.String()
+}
+
+func (is InsertStatement) TokenLiteral() string {
+	var str strings.Builder
+	if is.CTE != nil {
+		str.WriteString(fmt.Sprintf("%s ", cteToString(is.WithKeyword, is.CTE)))
+	}
+	str.WriteString(is.InsertKeyword.TokenLiteral())
+
+	if is.Top != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.Top.TokenLiteral()))
+	}
+
+	if is.IntoKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.IntoKeyword.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s", is.Target.TokenLiteral()))
+
+	if is.Columns != nil {
+		str.WriteString(fmt.Sprintf(" (%s)", is.Columns.TokenLiteral()))
+	}
+
+	if is.OutputClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.OutputClause.TokenLiteral()))
+	}
+
+	if is.Values != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.Values.TokenLiteral()))
+	} else if is.Query != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.Query.TokenLiteral()))
+	} else if is.Execute != nil {
+		str.WriteString(fmt.Sprintf(" %s", is.Execute.TokenLiteral()))
+	} else if is.DefaultValuesKeyword != nil {
+		for _, k := range is.DefaultValuesKeyword {
+			str.WriteString(fmt.Sprintf(" %s", k.TokenLiteral()))
+		}
+	}
+
+	return str.String()
+}
+func (es ExecuteStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(es.ExecKeyword.TokenLiteral())
+
+	if es.Command != nil {
+		str.WriteString(fmt.Sprintf(" (%s)", es.Command.TokenLiteral()))
+		return str.String()
+	}
+	if es.ReturnStatus != nil {
+		str.WriteString(fmt.Sprintf(" %s =", es.ReturnStatus.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s", es.Name.TokenLiteral()))
+
+	for i, arg := range es.Arguments {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString(fmt.Sprintf(" %s", arg.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (ea ExecuteArgument) TokenLiteral() string {
+	var str strings.Builder
+	if ea.Name != nil {
+		str.WriteString(fmt.Sprintf("%s = ", ea.Name.TokenLiteral()))
+	}
+	if ea.DefaultKeyword != nil {
+		str.WriteString(ea.DefaultKeyword.TokenLiteral())
+	} else {
+		str.WriteString(ea.Value.TokenLiteral())
+	}
+	if ea.OutputKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ea.OutputKeyword.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (us UpdateStatement) TokenLiteral() string {
+	var str strings.Builder
+	if us.CTE != nil {
+		str.WriteString(fmt.Sprintf("%s ", cteToString(us.WithKeyword, us.CTE)))
+	}
+	str.WriteString(us.UpdateKeyword.TokenLiteral())
+
+	if us.Top != nil {
+		str.WriteString(fmt.Sprintf(" %s", us.Top.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s %s ", us.Target.TokenLiteral(), us.SetKeyword.TokenLiteral()))
+	str.WriteString(expressionListToString(us.Assignments, ", "))
+
+	if us.OutputClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", us.OutputClause.TokenLiteral()))
+	}
+
+	if us.Table != nil {
+		str.WriteString(us.Table.TokenLiteral())
+	}
+
+	if us.WhereClause != nil {
+		str.WriteString(us.WhereClause.TokenLiteral())
+	}
 
 	return str.String()
 }
+func (del DeleteStatement) TokenLiteral() string {
+	var str strings.Builder
+	if del.CTE != nil {
+		str.WriteString(fmt.Sprintf("%s ", cteToString(del.WithKeyword, del.CTE)))
+	}
+	str.WriteString(del.DeleteKeyword.TokenLiteral())
 
-func (ss *SelectStatement) GetSpan() Span { return ss.Span }
-func (sb *SelectBody) GetSpan() Span      { return sb.Span }
+	if del.Top != nil {
+		str.WriteString(fmt.Sprintf(" %s", del.Top.TokenLiteral()))
+	}
 
-func (sb *SelectBody) SetSpan(span Span)      { sb.Span = span }
-func (ss *SelectStatement) SetSpan(span Span) { ss.Span = span }
+	if del.FromKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", del.FromKeyword.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s", del.Target.TokenLiteral()))
+
+	if del.OutputClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", del.OutputClause.TokenLiteral()))
+	}
+
+	if del.Table != nil {
+		str.WriteString(del.Table.TokenLiteral())
+	}
+
+	if del.WhereClause != nil {
+		str.WriteString(del.WhereClause.TokenLiteral())
+	}
+
+	return str.String()
+}
+func (ms MergeStatement) TokenLiteral() string {
+	var str strings.Builder
+	if ms.CTE != nil {
+		str.WriteString(fmt.Sprintf("%s ", cteToString(ms.WithKeyword, ms.CTE)))
+	}
+	str.WriteString(ms.MergeKeyword.TokenLiteral())
+
+	if ms.Top != nil {
+		str.WriteString(fmt.Sprintf(" %s", ms.Top.TokenLiteral()))
+	}
+
+	if ms.IntoKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ms.IntoKeyword.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s %s %s %s %s",
+		ms.Target.TokenLiteral(),
+		ms.UsingKeyword.TokenLiteral(),
+		ms.Source.TokenLiteral(),
+		ms.OnKeyword.TokenLiteral(),
+		ms.Condition.TokenLiteral(),
+	))
+
+	for _, w := range ms.WhenClauses {
+		str.WriteString(fmt.Sprintf(" %s", w.TokenLiteral()))
+	}
+
+	if ms.OutputClause != nil {
+		str.WriteString(fmt.Sprintf(" %s", ms.OutputClause.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (mw MergeWhenClause) TokenLiteral() string {
+	var str strings.Builder
+	for i, k := range mw.WhenKeywords {
+		if i > 0 {
+			str.WriteString(" ")
+		}
+		str.WriteString(k.TokenLiteral())
+	}
+
+	if mw.AndKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", mw.AndKeyword.TokenLiteral(), mw.Condition.TokenLiteral()))
+	}
+
+	str.WriteString(fmt.Sprintf(" %s %s", mw.ThenKeyword.TokenLiteral(), mw.Action.TokenLiteral()))
+	return str.String()
+}
+func (mu MergeUpdateAction) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s",
+		mu.UpdateKeyword.TokenLiteral(),
+		mu.SetKeyword.TokenLiteral(),
+		expressionListToString(mu.Assignments, ", "),
+	)
+}
+func (mi MergeInsertAction) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(mi.InsertKeyword.TokenLiteral())
+
+	if mi.Columns != nil {
+		str.WriteString(fmt.Sprintf(" (%s)", mi.Columns.TokenLiteral()))
+	}
+
+	if mi.Values != nil {
+		str.WriteString(fmt.Sprintf(" %s", mi.Values.TokenLiteral()))
+	} else if mi.DefaultValuesKeyword != nil {
+		for _, k := range mi.DefaultValuesKeyword {
+			str.WriteString(fmt.Sprintf(" %s", k.TokenLiteral()))
+		}
+	}
+
+	return str.String()
+}
+func (md MergeDeleteAction) TokenLiteral() string {
+	return md.DeleteKeyword.TokenLiteral()
+}
+func (blk BlockStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(blk.BeginKeyword.TokenLiteral())
+	for _, s := range blk.Statements {
+		str.WriteString(fmt.Sprintf(" %s;", s.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s", blk.EndKeyword.TokenLiteral()))
+
+	return str.String()
+}
+func (ifs IfStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s %s",
+		ifs.IfKeyword.TokenLiteral(),
+		ifs.Condition.TokenLiteral(),
+		ifs.Then.TokenLiteral(),
+	))
+
+	if ifs.ElseKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s %s", ifs.ElseKeyword.TokenLiteral(), ifs.Else.TokenLiteral()))
+	}
+
+	return str.String()
+}
+func (ws WhileStatement) TokenLiteral() string {
+	return fmt.Sprintf("%s %s %s",
+		ws.WhileKeyword.TokenLiteral(),
+		ws.Condition.TokenLiteral(),
+		ws.Body.TokenLiteral(),
+	)
+}
+func (bs BreakStatement) TokenLiteral() string {
+	return bs.BreakKeyword.TokenLiteral()
+}
+func (cs ContinueStatement) TokenLiteral() string {
+	return cs.ContinueKeyword.TokenLiteral()
+}
+func (rs ReturnStatement) TokenLiteral() string {
+	if rs.Value == nil {
+		return rs.ReturnKeyword.TokenLiteral()
+	}
+
+	return fmt.Sprintf("%s %s", rs.ReturnKeyword.TokenLiteral(), rs.Value.TokenLiteral())
+}
+func (tc TryCatchStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s %s", tc.BeginTryKeywords[0].TokenLiteral(), tc.BeginTryKeywords[1].TokenLiteral()))
+	for _, s := range tc.TryStatements {
+		str.WriteString(fmt.Sprintf(" %s;", s.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s %s", tc.EndTryKeywords[0].TokenLiteral(), tc.EndTryKeywords[1].TokenLiteral()))
+
+	str.WriteString(fmt.Sprintf(" %s %s", tc.BeginCatchKeywords[0].TokenLiteral(), tc.BeginCatchKeywords[1].TokenLiteral()))
+	for _, s := range tc.CatchStatements {
+		str.WriteString(fmt.Sprintf(" %s;", s.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s %s", tc.EndCatchKeywords[0].TokenLiteral(), tc.EndCatchKeywords[1].TokenLiteral()))
+
+	return str.String()
+}
+func (ts ThrowStatement) TokenLiteral() string {
+	if ts.ErrorNumber == nil {
+		return ts.ThrowKeyword.TokenLiteral()
+	}
+
+	return fmt.Sprintf("%s %s, %s, %s",
+		ts.ThrowKeyword.TokenLiteral(),
+		ts.ErrorNumber.TokenLiteral(),
+		ts.Message.TokenLiteral(),
+		ts.State.TokenLiteral(),
+	)
+}
+func (ra RaiserrorStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("%s(%s)",
+		ra.RaiserrorKeyword.TokenLiteral(),
+		expressionListToString(ra.Arguments, ", "),
+	))
+
+	if ra.WithKeyword != nil {
+		str.WriteString(fmt.Sprintf(" %s", ra.WithKeyword.TokenLiteral()))
+		for i, k := range ra.Options {
+			if i > 0 {
+				str.WriteString(",")
+			}
+			str.WriteString(fmt.Sprintf(" %s", k.TokenLiteral()))
+		}
+	}
+
+	return str.String()
+}
+func (ps PrintStatement) TokenLiteral() string {
+	return fmt.Sprintf("%s %s", ps.PrintKeyword.TokenLiteral(), ps.Value.TokenLiteral())
+}
+func (bt BeginTransactionStatement) TokenLiteral() string {
+	return transactionToString(bt.BeginKeyword, &bt.TransactionKeyword, bt.Name)
+}
+func (ct CommitTransactionStatement) TokenLiteral() string {
+	return transactionToString(ct.CommitKeyword, ct.TransactionKeyword, ct.Name)
+}
+func (rt RollbackTransactionStatement) TokenLiteral() string {
+	return transactionToString(rt.RollbackKeyword, rt.TransactionKeyword, rt.Name)
+}
+func (st SaveTransactionStatement) TokenLiteral() string {
+	return transactionToString(st.SaveKeyword, &st.TransactionKeyword, st.Name)
+}
+func (sop SetOptionStatement) TokenLiteral() string {
+	var str strings.Builder
+	str.WriteString(sop.SetKeyword.TokenLiteral())
+	for i, k := range sop.Options {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString(fmt.Sprintf(" %s", k.TokenLiteral()))
+	}
+	str.WriteString(fmt.Sprintf(" %s", sop.Value.TokenLiteral()))
+
+	return str.String()
+}
+
+func (ds *DeclareStatement) GetSpan() Span             { return ds.Span }
+func (vd *VariableDeclaration) GetSpan() Span          { return vd.Span }
+func (tv *TableVariableDeclaration) GetSpan() Span     { return tv.Span }
+func (cd *CursorDeclaration) GetSpan() Span            { return cd.Span }
+func (sv *SetLocalVariableStatement) GetSpan() Span    { return sv.Span }
+func (ss *SelectStatement) GetSpan() Span              { return ss.Span }
+func (sb *SelectBody) GetSpan() Span                   { return sb.Span }
+func (so *SetOperation) GetSpan() Span                 { return so.Span }
+func (pq *ParenthesizedQuery) GetSpan() Span           { return pq.Span }
+func (is *InsertStatement) GetSpan() Span              { return is.Span }
+func (es *ExecuteStatement) GetSpan() Span             { return es.Span }
+func (ea *ExecuteArgument) GetSpan() Span              { return ea.Span }
+func (us *UpdateStatement) GetSpan() Span              { return us.Span }
+func (del *DeleteStatement) GetSpan() Span             { return del.Span }
+func (ms *MergeStatement) GetSpan() Span               { return ms.Span }
+func (mw *MergeWhenClause) GetSpan() Span              { return mw.Span }
+func (mu *MergeUpdateAction) GetSpan() Span            { return mu.Span }
+func (mi *MergeInsertAction) GetSpan() Span            { return mi.Span }
+func (md *MergeDeleteAction) GetSpan() Span            { return md.Span }
+func (blk *BlockStatement) GetSpan() Span              { return blk.Span }
+func (ifs *IfStatement) GetSpan() Span                 { return ifs.Span }
+func (ws *WhileStatement) GetSpan() Span               { return ws.Span }
+func (bs *BreakStatement) GetSpan() Span               { return bs.Span }
+func (cs *ContinueStatement) GetSpan() Span            { return cs.Span }
+func (rs *ReturnStatement) GetSpan() Span              { return rs.Span }
+func (tc *TryCatchStatement) GetSpan() Span            { return tc.Span }
+func (ts *ThrowStatement) GetSpan() Span               { return ts.Span }
+func (ra *RaiserrorStatement) GetSpan() Span           { return ra.Span }
+func (ps *PrintStatement) GetSpan() Span               { return ps.Span }
+func (bt *BeginTransactionStatement) GetSpan() Span    { return bt.Span }
+func (ct *CommitTransactionStatement) GetSpan() Span   { return ct.Span }
+func (rt *RollbackTransactionStatement) GetSpan() Span { return rt.Span }
+func (st *SaveTransactionStatement) GetSpan() Span     { return st.Span }
+func (sop *SetOptionStatement) GetSpan() Span          { return sop.Span }
+
+func (ds *DeclareStatement) SetSpan(span Span)             { ds.Span = span }
+func (vd *VariableDeclaration) SetSpan(span Span)          { vd.Span = span }
+func (tv *TableVariableDeclaration) SetSpan(span Span)     { tv.Span = span }
+func (cd *CursorDeclaration) SetSpan(span Span)            { cd.Span = span }
+func (sv *SetLocalVariableStatement) SetSpan(span Span)    { sv.Span = span }
+func (sb *SelectBody) SetSpan(span Span)                   { sb.Span = span }
+func (ss *SelectStatement) SetSpan(span Span)              { ss.Span = span }
+func (so *SetOperation) SetSpan(span Span)                 { so.Span = span }
+func (pq *ParenthesizedQuery) SetSpan(span Span)           { pq.Span = span }
+func (is *InsertStatement) SetSpan(span Span)              { is.Span = span }
+func (es *ExecuteStatement) SetSpan(span Span)             { es.Span = span }
+func (ea *ExecuteArgument) SetSpan(span Span)              { ea.Span = span }
+func (us *UpdateStatement) SetSpan(span Span)              { us.Span = span }
+func (del *DeleteStatement) SetSpan(span Span)             { del.Span = span }
+func (ms *MergeStatement) SetSpan(span Span)               { ms.Span = span }
+func (mw *MergeWhenClause) SetSpan(span Span)              { mw.Span = span }
+func (mu *MergeUpdateAction) SetSpan(span Span)            { mu.Span = span }
+func (mi *MergeInsertAction) SetSpan(span Span)            { mi.Span = span }
+func (md *MergeDeleteAction) SetSpan(span Span)            { md.Span = span }
+func (blk *BlockStatement) SetSpan(span Span)              { blk.Span = span }
+func (ifs *IfStatement) SetSpan(span Span)                 { ifs.Span = span }
+func (ws *WhileStatement) SetSpan(span Span)               { ws.Span = span }
+func (bs *BreakStatement) SetSpan(span Span)               { bs.Span = span }
+func (cs *ContinueStatement) SetSpan(span Span)            { cs.Span = span }
+func (rs *ReturnStatement) SetSpan(span Span)              { rs.Span = span }
+func (tc *TryCatchStatement) SetSpan(span Span)            { tc.Span = span }
+func (ts *ThrowStatement) SetSpan(span Span)               { ts.Span = span }
+func (ra *RaiserrorStatement) SetSpan(span Span)           { ra.Span = span }
+func (ps *PrintStatement) SetSpan(span Span)               { ps.Span = span }
+func (bt *BeginTransactionStatement) SetSpan(span Span)    { bt.Span = span }
+func (ct *CommitTransactionStatement) SetSpan(span Span)   { ct.Span = span }
+func (rt *RollbackTransactionStatement) SetSpan(span Span) { rt.Span = span }
+func (st *SaveTransactionStatement) SetSpan(span Span)     { st.Span = span }
+func (sop *SetOptionStatement) SetSpan(span Span)          { sop.Span = span }
+
+type SetOperatorType uint8
+
+const (
+	SOUnion SetOperatorType = iota
+	SOUnionAll
+	SOExcept
+	SOIntersect
+)
+
+type MergeWhenType uint8
+
+const (
+	MWMatched MergeWhenType = iota
+	MWNotMatchedByTarget
+	MWNotMatchedBySource
+)
@@ -21,7 +21,18 @@ func Walk(v Visitor, node Node) {
 	case *Comment:
 		break
 	case *Query:
+		for i := range n.Batches {
+			Walk(v, &n.Batches[i])
+		}
+		break
+	case *Batch:
 		walkList(v, n.Statements)
+		if n.GoKeyword != nil {
+			Walk(v, n.GoKeyword)
+		}
+		if n.Count != nil {
+			Walk(v, n.Count)
+		}
 		break
 	case *SelectStatement:
 		if n.WithKeyword != nil {
@@ -32,7 +43,619 @@ func Walk(v Visitor, node Node) {
 				Walk(v, &cte)
 			}
 		}
-		Walk(v, n.SelectBody)
+		Walk(v, n.Query)
+		break
+	case *SetOperation:
+		Walk(v, n.Left)
+		for i := range n.OperatorKeywords {
+			Walk(v, &n.OperatorKeywords[i])
+		}
+		Walk(v, n.Right)
+		if n.OrderByClause != nil {
+			Walk(v, n.OrderByClause)
+		}
+		if n.ForClause != nil {
+			Walk(v, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			Walk(v, n.OptionClause)
+		}
+		break
+	case *ParenthesizedQuery:
+		Walk(v, n.Query)
+		if n.OrderByClause != nil {
+			Walk(v, n.OrderByClause)
+		}
+		if n.ForClause != nil {
+			Walk(v, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			Walk(v, n.OptionClause)
+		}
+		break
+	case *InsertStatement:
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		if n.CTE != nil {
+			for i := range *n.CTE {
+				Walk(v, &(*n.CTE)[i])
+			}
+		}
+		Walk(v, &n.InsertKeyword)
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
+		if n.IntoKeyword != nil {
+			Walk(v, n.IntoKeyword)
+		}
+		Walk(v, n.Target)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
+		if n.OutputClause != nil {
+			Walk(v, n.OutputClause)
+		}
+		if n.Values != nil {
+			Walk(v, n.Values)
+		}
+		if n.Query != nil {
+			Walk(v, n.Query)
+		}
+		if n.Execute != nil {
+			Walk(v, n.Execute)
+		}
+		if n.DefaultValuesKeyword != nil {
+			for i := range n.DefaultValuesKeyword {
+				Walk(v, &n.DefaultValuesKeyword[i])
+			}
+		}
+		break
+	case *UpdateStatement:
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		if n.CTE != nil {
+			for i := range *n.CTE {
+				Walk(v, &(*n.CTE)[i])
+			}
+		}
+		Walk(v, &n.UpdateKeyword)
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
+		Walk(v, n.Target)
+		Walk(v, &n.SetKeyword)
+		walkList(v, n.Assignments)
+		if n.OutputClause != nil {
+			Walk(v, n.OutputClause)
+		}
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
+		break
+	case *DeleteStatement:
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		if n.CTE != nil {
+			for i := range *n.CTE {
+				Walk(v, &(*n.CTE)[i])
+			}
+		}
+		Walk(v, &n.DeleteKeyword)
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
+		if n.FromKeyword != nil {
+			Walk(v, n.FromKeyword)
+		}
+		Walk(v, n.Target)
+		if n.OutputClause != nil {
+			Walk(v, n.OutputClause)
+		}
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
+		break
+	case *BlockStatement:
+		Walk(v, &n.BeginKeyword)
+		walkList(v, n.Statements)
+		Walk(v, &n.EndKeyword)
+		break
+	case *IfStatement:
+		Walk(v, &n.IfKeyword)
+		Walk(v, n.Condition)
+		Walk(v, n.Then)
+		if n.ElseKeyword != nil {
+			Walk(v, n.ElseKeyword)
+			Walk(v, n.Else)
+		}
+		break
+	case *WhileStatement:
+		Walk(v, &n.WhileKeyword)
+		Walk(v, n.Condition)
+		Walk(v, n.Body)
+		break
+	case *BreakStatement:
+		Walk(v, &n.BreakKeyword)
+		break
+	case *ContinueStatement:
+		Walk(v, &n.ContinueKeyword)
+		break
+	case *ReturnStatement:
+		Walk(v, &n.ReturnKeyword)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		break
+	case *TryCatchStatement:
+		for i := range n.BeginTryKeywords {
+			Walk(v, &n.BeginTryKeywords[i])
+		}
+		walkList(v, n.TryStatements)
+		for i := range n.EndTryKeywords {
+			Walk(v, &n.EndTryKeywords[i])
+		}
+		for i := range n.BeginCatchKeywords {
+			Walk(v, &n.BeginCatchKeywords[i])
+		}
+		walkList(v, n.CatchStatements)
+		for i := range n.EndCatchKeywords {
+			Walk(v, &n.EndCatchKeywords[i])
+		}
+		break
+	case *ThrowStatement:
+		Walk(v, &n.ThrowKeyword)
+		if n.ErrorNumber != nil {
+			Walk(v, n.ErrorNumber)
+			Walk(v, n.Message)
+			Walk(v, n.State)
+		}
+		break
+	case *RaiserrorStatement:
+		Walk(v, &n.RaiserrorKeyword)
+		walkList(v, n.Arguments)
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		break
+	case *PrintStatement:
+		Walk(v, &n.PrintKeyword)
+		Walk(v, n.Value)
+		break
+	case *BeginTransactionStatement:
+		Walk(v, &n.BeginKeyword)
+		Walk(v, &n.TransactionKeyword)
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		break
+	case *CommitTransactionStatement:
+		Walk(v, &n.CommitKeyword)
+		if n.TransactionKeyword != nil {
+			Walk(v, n.TransactionKeyword)
+		}
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		break
+	case *RollbackTransactionStatement:
+		Walk(v, &n.RollbackKeyword)
+		if n.TransactionKeyword != nil {
+			Walk(v, n.TransactionKeyword)
+		}
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		break
+	case *SaveTransactionStatement:
+		Walk(v, &n.SaveKeyword)
+		Walk(v, &n.TransactionKeyword)
+		Walk(v, n.Name)
+		break
+	case *SetOptionStatement:
+		Walk(v, &n.SetKeyword)
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		Walk(v, &n.Value)
+		break
+	case *DeclareStatement:
+		Walk(v, &n.DeclareKeyword)
+		for i := range n.Variables {
+			Walk(v, &n.Variables[i])
+		}
+		if n.TableVariable != nil {
+			Walk(v, n.TableVariable)
+		}
+		if n.Cursor != nil {
+			Walk(v, n.Cursor)
+		}
+		break
+	case *VariableDeclaration:
+		Walk(v, &n.Name)
+		if n.AsKeyword != nil {
+			Walk(v, n.AsKeyword)
+		}
+		Walk(v, n.DataType)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		break
+	case *TableVariableDeclaration:
+		Walk(v, &n.Name)
+		if n.AsKeyword != nil {
+			Walk(v, n.AsKeyword)
+		}
+		Walk(v, &n.TableKeyword)
+		walkList(v, n.Definitions)
+		break
+	case *CursorDeclaration:
+		Walk(v, n.Name)
+		Walk(v, &n.CursorKeyword)
+		Walk(v, &n.ForKeyword)
+		Walk(v, n.Query)
+		break
+	case *CreateTableStatement:
+		Walk(v, &n.CreateKeyword)
+		Walk(v, &n.TableKeyword)
+		Walk(v, n.Name)
+		walkList(v, n.Definitions)
+		break
+	case *AlterTableStatement:
+		Walk(v, &n.AlterKeyword)
+		Walk(v, &n.TableKeyword)
+		Walk(v, n.Name)
+		Walk(v, n.Action)
+		break
+	case *DropTableStatement:
+		Walk(v, &n.DropKeyword)
+		Walk(v, &n.TableKeyword)
+		for i := range n.IfExistsKeywords {
+			Walk(v, &n.IfExistsKeywords[i])
+		}
+		walkList(v, n.Names)
+		break
+	case *CreateProcedureStatement:
+		for i := range n.CreateKeywords {
+			Walk(v, &n.CreateKeywords[i])
+		}
+		Walk(v, &n.ProcedureKeyword)
+		Walk(v, n.Name)
+		for i := range n.Parameters {
+			Walk(v, &n.Parameters[i])
+		}
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		Walk(v, &n.AsKeyword)
+		walkList(v, n.Statements)
+		break
+	case *CreateFunctionStatement:
+		for i := range n.CreateKeywords {
+			Walk(v, &n.CreateKeywords[i])
+		}
+		Walk(v, &n.FunctionKeyword)
+		Walk(v, n.Name)
+		for i := range n.Parameters {
+			Walk(v, &n.Parameters[i])
+		}
+		Walk(v, &n.ReturnsKeyword)
+		if n.ReturnType != nil {
+			Walk(v, n.ReturnType)
+		}
+		if n.ReturnTableKeyword != nil {
+			Walk(v, n.ReturnTableKeyword)
+		}
+		if n.ReturnVariable != nil {
+			Walk(v, n.ReturnVariable)
+		}
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		if n.AsKeyword != nil {
+			Walk(v, n.AsKeyword)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+		if n.ReturnKeyword != nil {
+			Walk(v, n.ReturnKeyword)
+			Walk(v, n.ReturnQuery)
+		}
+		break
+	case *CreateViewStatement:
+		for i := range n.CreateKeywords {
+			Walk(v, &n.CreateKeywords[i])
+		}
+		Walk(v, &n.ViewKeyword)
+		Walk(v, n.Name)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		Walk(v, &n.AsKeyword)
+		Walk(v, n.Query)
+		break
+	case *CreateIndexStatement:
+		Walk(v, &n.CreateKeyword)
+		if n.UniqueKeyword != nil {
+			Walk(v, n.UniqueKeyword)
+		}
+		if n.ClusteredKeyword != nil {
+			Walk(v, n.ClusteredKeyword)
+		}
+		Walk(v, &n.IndexKeyword)
+		Walk(v, n.Name)
+		Walk(v, &n.OnKeyword)
+		Walk(v, n.Table)
+		for i := range n.Columns {
+			Walk(v, &n.Columns[i])
+		}
+		if n.IncludeKeyword != nil {
+			Walk(v, n.IncludeKeyword)
+			Walk(v, n.IncludeColumns)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		break
+	case *IndexOption:
+		Walk(v, n.Name)
+		if n.ValueKeyword != nil {
+			Walk(v, n.ValueKeyword)
+		} else {
+			Walk(v, n.Value)
+		}
+		break
+	case *CreateTriggerStatement:
+		for i := range n.CreateKeywords {
+			Walk(v, &n.CreateKeywords[i])
+		}
+		Walk(v, &n.TriggerKeyword)
+		Walk(v, n.Name)
+		Walk(v, &n.OnKeyword)
+		Walk(v, n.Table)
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		for i := range n.TimingKeywords {
+			Walk(v, &n.TimingKeywords[i])
+		}
+		for i := range n.EventKeywords {
+			Walk(v, &n.EventKeywords[i])
+		}
+		Walk(v, &n.AsKeyword)
+		walkList(v, n.Statements)
+		break
+	case *RoutineParameter:
+		Walk(v, &n.Name)
+		if n.AsKeyword != nil {
+			Walk(v, n.AsKeyword)
+		}
+		if n.DataType != nil {
+			Walk(v, n.DataType)
+		} else {
+			Walk(v, n.TypeName)
+		}
+		if n.Default != nil {
+			Walk(v, n.Default)
+		}
+		for i := range n.OptionKeywords {
+			Walk(v, &n.OptionKeywords[i])
+		}
+		break
+	case *RoutineOption:
+		for i := range n.Keywords {
+			Walk(v, &n.Keywords[i])
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		break
+	case *ColumnDefinition:
+		Walk(v, n.Name)
+		if n.AsKeyword != nil {
+			Walk(v, n.AsKeyword)
+			Walk(v, n.ComputedValue)
+		} else {
+			Walk(v, n.DataType)
+		}
+		if n.PersistedKeyword != nil {
+			Walk(v, n.PersistedKeyword)
+		}
+		if n.Identity != nil {
+			Walk(v, n.Identity)
+		}
+		for i := range n.NullKeywords {
+			Walk(v, &n.NullKeywords[i])
+		}
+		for i := range n.Constraints {
+			Walk(v, &n.Constraints[i])
+		}
+		break
+	case *IdentityProperty:
+		Walk(v, &n.IdentityKeyword)
+		if n.Seed != nil {
+			Walk(v, n.Seed)
+			Walk(v, n.Increment)
+		}
+		break
+	case *TableConstraint:
+		if n.ConstraintKeyword != nil {
+			Walk(v, n.ConstraintKeyword)
+			Walk(v, n.Name)
+		}
+		for i := range n.TypeKeywords {
+			Walk(v, &n.TypeKeywords[i])
+		}
+		if n.ClusteredKeyword != nil {
+			Walk(v, n.ClusteredKeyword)
+		}
+		for i := range n.Columns {
+			Walk(v, &n.Columns[i])
+		}
+		if n.ReferencesKeyword != nil {
+			Walk(v, n.ReferencesKeyword)
+			Walk(v, n.ReferencedTable)
+		}
+		if n.ReferencedColumns != nil {
+			Walk(v, n.ReferencedColumns)
+		}
+		for i := range n.OnDeleteKeywords {
+			Walk(v, &n.OnDeleteKeywords[i])
+		}
+		for i := range n.OnUpdateKeywords {
+			Walk(v, &n.OnUpdateKeywords[i])
+		}
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+		if n.ForKeyword != nil {
+			Walk(v, n.ForKeyword)
+			Walk(v, n.ForColumn)
+		}
+		break
+	case *AlterTableAddAction:
+		Walk(v, &n.AddKeyword)
+		walkList(v, n.Definitions)
+		break
+	case *AlterTableDropAction:
+		Walk(v, &n.DropKeyword)
+		if n.TargetKeyword != nil {
+			Walk(v, n.TargetKeyword)
+		}
+		for i := range n.IfExistsKeywords {
+			Walk(v, &n.IfExistsKeywords[i])
+		}
+		walkList(v, n.Names)
+		break
+	case *AlterTableAlterColumnAction:
+		Walk(v, &n.AlterKeyword)
+		Walk(v, &n.ColumnKeyword)
+		Walk(v, n.Column)
+		break
+	case *SetLocalVariableStatement:
+		Walk(v, &n.SetKeyword)
+		Walk(v, n.Assignment)
+		break
+	case *MergeStatement:
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		if n.CTE != nil {
+			for i := range *n.CTE {
+				Walk(v, &(*n.CTE)[i])
+			}
+		}
+		Walk(v, &n.MergeKeyword)
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
+		if n.IntoKeyword != nil {
+			Walk(v, n.IntoKeyword)
+		}
+		Walk(v, n.Target)
+		Walk(v, &n.UsingKeyword)
+		Walk(v, n.Source)
+		Walk(v, &n.OnKeyword)
+		Walk(v, n.Condition)
+		for i := range n.WhenClauses {
+			Walk(v, &n.WhenClauses[i])
+		}
+		if n.OutputClause != nil {
+			Walk(v, n.OutputClause)
+		}
+		break
+	case *MergeWhenClause:
+		for i := range n.WhenKeywords {
+			Walk(v, &n.WhenKeywords[i])
+		}
+		if n.AndKeyword != nil {
+			Walk(v, n.AndKeyword)
+			Walk(v, n.Condition)
+		}
+		Walk(v, &n.ThenKeyword)
+		Walk(v, n.Action)
+		break
+	case *MergeUpdateAction:
+		Walk(v, &n.UpdateKeyword)
+		Walk(v, &n.SetKeyword)
+		walkList(v, n.Assignments)
+		break
+	case *MergeInsertAction:
+		Walk(v, &n.InsertKeyword)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
+		if n.Values != nil {
+			Walk(v, n.Values)
+		}
+		if n.DefaultValuesKeyword != nil {
+			for i := range n.DefaultValuesKeyword {
+				Walk(v, &n.DefaultValuesKeyword[i])
+			}
+		}
+		break
+	case *MergeDeleteAction:
+		Walk(v, &n.DeleteKeyword)
+		break
+	case *ExecuteStatement:
+		Walk(v, &n.ExecKeyword)
+		if n.Command != nil {
+			Walk(v, n.Command)
+			break
+		}
+		if n.ReturnStatus != nil {
+			Walk(v, n.ReturnStatus)
+		}
+		Walk(v, n.Name)
+		for i := range n.Arguments {
+			Walk(v, &n.Arguments[i])
+		}
+		break
+	case *ExecuteArgument:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		if n.DefaultKeyword != nil {
+			Walk(v, n.DefaultKeyword)
+		} else {
+			Walk(v, n.Value)
+		}
+		if n.OutputKeyword != nil {
+			Walk(v, n.OutputKeyword)
+		}
 		break
 	case *SelectBody:
 		Walk(v, &n.SelectKeyword)
@@ -46,7 +669,12 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.Top)
 		}
 		Walk(v, &n.SelectItems)
-		Walk(v, n.Table)
+		if n.IntoClause != nil {
+			Walk(v, n.IntoClause)
+		}
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
 		if n.WhereClause != nil {
 			Walk(v, n.WhereClause)
 		}
@@ -59,6 +687,12 @@ func Walk(v Visitor, node Node) {
 		if n.OrderByClause != nil {
 			Walk(v, n.OrderByClause)
 		}
+		if n.ForClause != nil {
+			Walk(v, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			Walk(v, n.OptionClause)
+		}
 		break
 	case *ExprStringLiteral:
 		break
@@ -70,6 +704,8 @@ func Walk(v Visitor, node Node) {
 		break
 	case *ExprQuotedIdentifier:
 		break
+	case *ExprNullLiteral:
+		break
 	case *ExprStar:
 		break
 	case *ExprWithAlias:
@@ -84,6 +720,8 @@ func Walk(v Visitor, node Node) {
 		break
 	case *ExprBuiltInFunctionName:
 		break
+	case *ExprDatePart:
+		break
 	case *SelectItems:
 		walkList(v, n.Items)
 		break
@@ -95,12 +733,25 @@ func Walk(v Visitor, node Node) {
 		Walk(v, &n.HavingKeyword)
 		Walk(v, n.Clause)
 		break
+	case *IntoClause:
+		Walk(v, &n.IntoKeyword)
+		Walk(v, n.Target)
+		break
 	case *GroupByClause:
 		for _, k := range n.GroupByKeyword {
 			Walk(v, &k)
 		}
 		walkList(v, n.Items)
 		break
+	case *ExprGrouping:
+		for i := range n.Keywords {
+			Walk(v, &n.Keywords[i])
+		}
+		walkList(v, n.Sets)
+		break
+	case *ExprGroupingSet:
+		walkList(v, n.Items)
+		break
 	case *TableArg:
 		Walk(v, &n.FromKeyword)
 		Walk(v, n.Table)
@@ -110,6 +761,87 @@ func Walk(v Visitor, node Node) {
 		break
 	case *TableSource:
 		Walk(v, n.Source)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Hints {
+			Walk(v, &n.Hints[i])
+		}
+		break
+	case *TableHint:
+		Walk(v, &n.HintKeyword)
+		walkList(v, n.Indexes)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
+		break
+	case *OptionClause:
+		Walk(v, &n.OptionKeyword)
+		for i := range n.Hints {
+			Walk(v, &n.Hints[i])
+		}
+		break
+	case *QueryHint:
+		for i := range n.Keywords {
+			Walk(v, &n.Keywords[i])
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		for i := range n.Variables {
+			Walk(v, &n.Variables[i])
+		}
+		break
+	case *OptimizeForVariable:
+		Walk(v, &n.Variable)
+		if n.UnknownKeyword != nil {
+			Walk(v, n.UnknownKeyword)
+		} else {
+			Walk(v, n.Value)
+		}
+		break
+	case *ForClause:
+		Walk(v, &n.ForKeyword)
+		Walk(v, &n.ModeKeyword)
+		for i := range n.Options {
+			Walk(v, &n.Options[i])
+		}
+		break
+	case *ForOption:
+		for i := range n.Keywords {
+			Walk(v, &n.Keywords[i])
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		break
+	case *ExprRowsetFunction:
+		Walk(v, &n.FunctionKeyword)
+		walkList(v, n.Args)
+		if n.WithKeyword != nil {
+			Walk(v, n.WithKeyword)
+		}
+		for i := range n.Columns {
+			Walk(v, &n.Columns[i])
+		}
+		if n.SchemaTable != nil {
+			Walk(v, n.SchemaTable)
+		}
+		break
+	case *RowsetColumn:
+		Walk(v, n.Name)
+		Walk(v, &n.DataType)
+		if n.Path != nil {
+			Walk(v, n.Path)
+		}
+		if n.AsJsonKeyword != nil {
+			for i := range n.AsJsonKeyword {
+				Walk(v, &n.AsJsonKeyword[i])
+			}
+		}
 		break
 	case *Join:
 		for _, k := range n.JoinTypeKeyword {
@@ -170,7 +902,10 @@ func Walk(v Visitor, node Node) {
 		}
 		break
 	case *ExprSubquery:
-		Walk(v, &n.SelectBody)
+		Walk(v, n.Query)
+		break
+	case *ExprValuesTable:
+		Walk(v, &n.TableValueConstructor)
 		break
 	case *ExprExpressionList:
 		walkList(v, n.List)
@@ -220,19 +955,100 @@ func Walk(v Visitor, node Node) {
 	case *ExprFunctionCall:
 		Walk(v, n.Name)
 		walkList(v, n.Args)
+		if n.WithinGroupClause != nil {
+			Walk(v, n.WithinGroupClause)
+		}
 		if n.OverClause != nil {
 			Walk(v, n.OverClause)
 		}
 		break
+	case *WithinGroupClause:
+		for i := range n.WithinGroupKeyword {
+			Walk(v, &n.WithinGroupKeyword[i])
+		}
+		for i := range n.OrderByKeyword {
+			Walk(v, &n.OrderByKeyword[i])
+		}
+		for i := range n.OrderByClause {
+			Walk(v, &n.OrderByClause[i])
+		}
+		break
 	case *ExprCast:
 		Walk(v, &n.CastKeyword)
 		Walk(v, n.Expression)
 		Walk(v, &n.AsKeyword)
 		break
+	case *ExprConvert:
+		Walk(v, &n.ConvertKeyword)
+		Walk(v, n.Expression)
+		if n.Style != nil {
+			Walk(v, n.Style)
+		}
+		break
+	case *ExprParse:
+		Walk(v, &n.ParseKeyword)
+		Walk(v, n.Expression)
+		Walk(v, &n.AsKeyword)
+		if n.UsingKeyword != nil {
+			Walk(v, n.UsingKeyword)
+			Walk(v, n.Culture)
+		}
+		break
+	case *ExprCase:
+		Walk(v, &n.CaseKeyword)
+		if n.InputExpression != nil {
+			Walk(v, n.InputExpression)
+		}
+		for i := range n.WhenClauses {
+			Walk(v, &n.WhenClauses[i])
+		}
+		if n.ElseClause != nil {
+			Walk(v, n.ElseClause)
+		}
+		Walk(v, &n.EndKeyword)
+		break
+	case *WhenClause:
+		Walk(v, &n.WhenKeyword)
+		Walk(v, n.Condition)
+		Walk(v, &n.ThenKeyword)
+		Walk(v, n.Result)
+		break
+	case *ElseClause:
+		Walk(v, &n.ElseKeyword)
+		Walk(v, n.Result)
+		break
 	case *CommonTableExpression:
-		Walk(v, n.Columns)
+		if n.Columns != nil {
+			Walk(v, n.Columns)
+		}
 		Walk(v, &n.AsKeyword)
-		Walk(v, &n.Query)
+		Walk(v, n.Query)
+		break
+	case *OutputClause:
+		Walk(v, &n.OutputKeyword)
+		Walk(v, &n.Columns)
+		if n.IntoKeyword != nil {
+			Walk(v, n.IntoKeyword)
+			Walk(v, n.IntoTarget)
+		}
+		if n.IntoColumns != nil {
+			Walk(v, n.IntoColumns)
+		}
+		break
+	case *ExprPivot:
+		Walk(v, n.Source)
+		Walk(v, &n.PivotKeyword)
+		Walk(v, n.Aggregate)
+		Walk(v, &n.ForKeyword)
+		Walk(v, n.Column)
+		Walk(v, &n.InKeyword)
+		walkList(v, n.Values)
+		break
+	case *TableValueConstructor:
+		Walk(v, &n.ValuesKeyword)
+		for i := range n.Rows {
+			Walk(v, &n.Rows[i])
+		}
 		break
 	case *DataType:
 		Walk(v, n.DecimalNumericSize)
@@ -250,6 +1066,10 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Left)
 		Walk(v, n.Right)
 		break
+	case *ExprAssignmentOperator:
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+		break
 	case *ExprAndLogicalOperator:
 		Walk(v, n.Left)
 		Walk(v, &n.AndKeyword)
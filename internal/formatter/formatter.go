@@ -18,6 +18,9 @@ type Formatter struct {
 	queuedChars    string
 	currentLine    uint64
 	mappedComments MappedComments
+	// line widths the parts of column definitions are padded to
+	columnNameWidth     int
+	columnDataTypeWidth int
 }
 
 func NewFormatter(settings Settings, logger *zap.SugaredLogger) Formatter {
@@ -81,16 +84,896 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 
 	switch n := node.(type) {
 	case *ast.Query:
+		for i := range n.Batches {
+			if i > 0 {
+				f.printNewLine()
+				f.printNewLine()
+			}
+			ast.Walk(f, &n.Batches[i])
+		}
+		break
+	case *ast.Batch:
 		for i, s := range n.Statements {
 			if i > 0 {
-				f.printNewLine()
-				f.printNewLine()
+				f.printNewLine()
+				f.printNewLine()
+			}
+			ast.Walk(f, s)
+			f.printPrecedingTerminator(n.Statements, i)
+		}
+		if n.GoKeyword != nil {
+			if len(n.Statements) > 0 {
+				f.printNewLine()
+			}
+			ast.Walk(f, n.GoKeyword)
+			if n.Count != nil {
+				f.printSpace()
+				ast.Walk(f, n.Count)
+			}
+		}
+		break
+	case *ast.SelectStatement:
+		f.printCommonTableExpressions(n.WithKeyword, n.CTE)
+		ast.Walk(f, n.Query)
+		break
+	case *ast.SetOperation:
+		ast.Walk(f, n.Left)
+		f.printNewLine()
+		for i, k := range n.OperatorKeywords {
+			if i > 0 {
+				f.printSpace()
+			}
+			ast.Walk(f, &k)
+		}
+		f.printNewLine()
+		ast.Walk(f, n.Right)
+		if n.OrderByClause != nil {
+			ast.Walk(f, n.OrderByClause)
+		}
+		if n.ForClause != nil {
+			ast.Walk(f, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			ast.Walk(f, n.OptionClause)
+		}
+		break
+	case *ast.ParenthesizedQuery:
+		f.formattedQuery += "("
+		f.increaseIndent()
+		f.printNewLine()
+		ast.Walk(f, n.Query)
+		f.decreaseIndent()
+		f.printNewLine()
+		f.formattedQuery += ")"
+		if n.OrderByClause != nil {
+			ast.Walk(f, n.OrderByClause)
+		}
+		if n.ForClause != nil {
+			ast.Walk(f, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			ast.Walk(f, n.OptionClause)
+		}
+		break
+	case *ast.InsertStatement:
+		f.printCommonTableExpressions(n.WithKeyword, n.CTE)
+		ast.Walk(f, &n.InsertKeyword)
+		if n.Top != nil {
+			ast.Walk(f, n.Top)
+		}
+		if n.IntoKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.IntoKeyword)
+		}
+		f.printSpace()
+		ast.Walk(f, n.Target)
+		if n.Columns != nil {
+			f.printSpace()
+			f.printColumnList(n.Columns)
+		}
+		if n.OutputClause != nil {
+			ast.Walk(f, n.OutputClause)
+		}
+		f.printNewLine()
+		if n.Values != nil {
+			ast.Walk(f, n.Values)
+		} else if n.Query != nil {
+			ast.Walk(f, n.Query)
+		} else if n.Execute != nil {
+			ast.Walk(f, n.Execute)
+		} else if n.DefaultValuesKeyword != nil {
+			for i, k := range n.DefaultValuesKeyword {
+				if i > 0 {
+					f.printSpace()
+				}
+				ast.Walk(f, &k)
+			}
+		}
+		break
+	case *ast.UpdateStatement:
+		f.printCommonTableExpressions(n.WithKeyword, n.CTE)
+		ast.Walk(f, &n.UpdateKeyword)
+		if n.Top != nil {
+			ast.Walk(f, n.Top)
+		}
+		f.printSpace()
+		ast.Walk(f, n.Target)
+		f.printNewLine()
+		ast.Walk(f, &n.SetKeyword)
+		f.printAssignments(n.Assignments)
+		if n.OutputClause != nil {
+			ast.Walk(f, n.OutputClause)
+		}
+		if n.Table != nil {
+			ast.Walk(f, n.Table)
+		}
+		if n.WhereClause != nil {
+			ast.Walk(f, n.WhereClause)
+		}
+		break
+	case *ast.DeleteStatement:
+		f.printCommonTableExpressions(n.WithKeyword, n.CTE)
+		ast.Walk(f, &n.DeleteKeyword)
+		if n.Top != nil {
+			ast.Walk(f, n.Top)
+		}
+		if n.FromKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.FromKeyword)
+		}
+		f.printSpace()
+		ast.Walk(f, n.Target)
+		if n.OutputClause != nil {
+			ast.Walk(f, n.OutputClause)
+		}
+		if n.Table != nil {
+			ast.Walk(f, n.Table)
+		}
+		if n.WhereClause != nil {
+			ast.Walk(f, n.WhereClause)
+		}
+		break
+	case *ast.BlockStatement:
+		ast.Walk(f, &n.BeginKeyword)
+		f.printBlockStatements(n.Statements)
+		f.printNewLine()
+		ast.Walk(f, &n.EndKeyword)
+		break
+	case *ast.TryCatchStatement:
+		f.printKeywords(n.BeginTryKeywords[:])
+		f.printBlockStatements(n.TryStatements)
+		f.printNewLine()
+		f.printKeywords(n.EndTryKeywords[:])
+		f.printNewLine()
+		f.printKeywords(n.BeginCatchKeywords[:])
+		f.printBlockStatements(n.CatchStatements)
+		f.printNewLine()
+		f.printKeywords(n.EndCatchKeywords[:])
+		break
+	case *ast.ThrowStatement:
+		ast.Walk(f, &n.ThrowKeyword)
+		if n.ErrorNumber != nil {
+			f.printSpace()
+			ast.Walk(f, n.ErrorNumber)
+			f.printExpressionListComma()
+			ast.Walk(f, n.Message)
+			f.printExpressionListComma()
+			ast.Walk(f, n.State)
+		}
+		break
+	case *ast.RaiserrorStatement:
+		ast.Walk(f, &n.RaiserrorKeyword)
+		f.formattedQuery += "("
+		for i, e := range n.Arguments {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, e)
+		}
+		f.formattedQuery += ")"
+		if n.WithKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.WithKeyword)
+			f.printSpace()
+			for i := range n.Options {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, &n.Options[i])
+			}
+		}
+		break
+	case *ast.PrintStatement:
+		ast.Walk(f, &n.PrintKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Value)
+		break
+	case *ast.IfStatement:
+		ast.Walk(f, &n.IfKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Condition)
+		f.printStatementBody(n.Then)
+		if n.ElseKeyword != nil {
+			f.printNewLine()
+			ast.Walk(f, n.ElseKeyword)
+			// keep else if chains at the same level
+			if elseIf, ok := n.Else.(*ast.IfStatement); ok {
+				f.printSpace()
+				ast.Walk(f, elseIf)
+			} else {
+				f.printStatementBody(n.Else)
+			}
+		}
+		break
+	case *ast.WhileStatement:
+		ast.Walk(f, &n.WhileKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Condition)
+		f.printStatementBody(n.Body)
+		break
+	case *ast.BreakStatement:
+		ast.Walk(f, &n.BreakKeyword)
+		break
+	case *ast.ContinueStatement:
+		ast.Walk(f, &n.ContinueKeyword)
+		break
+	case *ast.ReturnStatement:
+		ast.Walk(f, &n.ReturnKeyword)
+		if n.Value != nil {
+			f.printSpace()
+			ast.Walk(f, n.Value)
+		}
+		break
+	case *ast.BeginTransactionStatement:
+		ast.Walk(f, &n.BeginKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.TransactionKeyword)
+		if n.Name != nil {
+			f.printSpace()
+			ast.Walk(f, n.Name)
+		}
+		break
+	case *ast.CommitTransactionStatement:
+		ast.Walk(f, &n.CommitKeyword)
+		if n.TransactionKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.TransactionKeyword)
+		}
+		if n.Name != nil {
+			f.printSpace()
+			ast.Walk(f, n.Name)
+		}
+		break
+	case *ast.RollbackTransactionStatement:
+		ast.Walk(f, &n.RollbackKeyword)
+		if n.TransactionKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.TransactionKeyword)
+		}
+		if n.Name != nil {
+			f.printSpace()
+			ast.Walk(f, n.Name)
+		}
+		break
+	case *ast.SaveTransactionStatement:
+		ast.Walk(f, &n.SaveKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.TransactionKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		break
+	case *ast.SetOptionStatement:
+		ast.Walk(f, &n.SetKeyword)
+		f.printSpace()
+		for i := range n.Options {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, &n.Options[i])
+		}
+		f.printSpace()
+		ast.Walk(f, &n.Value)
+		break
+	case *ast.DeclareStatement:
+		ast.Walk(f, &n.DeclareKeyword)
+		if n.TableVariable != nil {
+			f.printSpace()
+			ast.Walk(f, n.TableVariable)
+		} else if n.Cursor != nil {
+			f.printSpace()
+			ast.Walk(f, n.Cursor)
+		}
+		if len(n.Variables) > 1 {
+			f.increaseIndent()
+			f.printNewLine()
+		} else if len(n.Variables) == 1 {
+			f.printSpace()
+		}
+		for i := range n.Variables {
+			if i > 0 {
+				f.printSelectColumnComma()
+			}
+			ast.Walk(f, &n.Variables[i])
+		}
+		if len(n.Variables) > 1 {
+			f.decreaseIndent()
+		}
+		break
+	case *ast.VariableDeclaration:
+		ast.Walk(f, &n.Name)
+		f.printSpace()
+		if n.AsKeyword != nil {
+			ast.Walk(f, n.AsKeyword)
+			f.printSpace()
+		}
+		ast.Walk(f, n.DataType)
+		if n.Value != nil {
+			f.formattedQuery += " = "
+			ast.Walk(f, n.Value)
+		}
+		break
+	case *ast.TableVariableDeclaration:
+		ast.Walk(f, &n.Name)
+		f.printSpace()
+		if n.AsKeyword != nil {
+			ast.Walk(f, n.AsKeyword)
+			f.printSpace()
+		}
+		ast.Walk(f, &n.TableKeyword)
+		f.printSpace()
+		f.printParenthesizedTableElements(n.Definitions)
+		break
+	case *ast.CursorDeclaration:
+		ast.Walk(f, n.Name)
+		f.printSpace()
+		ast.Walk(f, &n.CursorKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.ForKeyword)
+		f.printNewLine()
+		ast.Walk(f, n.Query)
+		break
+	case *ast.CreateTableStatement:
+		ast.Walk(f, &n.CreateKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.TableKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		f.printSpace()
+		f.printParenthesizedTableElements(n.Definitions)
+		break
+	case *ast.AlterTableStatement:
+		ast.Walk(f, &n.AlterKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.TableKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		f.printSpace()
+		ast.Walk(f, n.Action)
+		break
+	case *ast.DropTableStatement:
+		ast.Walk(f, &n.DropKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.TableKeyword)
+		f.printSpace()
+		if len(n.IfExistsKeywords) > 0 {
+			f.printKeywords(n.IfExistsKeywords)
+			f.printSpace()
+		}
+		for i, name := range n.Names {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, name)
+		}
+		break
+	case *ast.AlterTableAddAction:
+		ast.Walk(f, &n.AddKeyword)
+		if len(n.Definitions) == 1 {
+			f.printSpace()
+			ast.Walk(f, n.Definitions[0])
+			break
+		}
+		f.increaseIndent()
+		f.printNewLine()
+		f.printTableElements(n.Definitions)
+		f.decreaseIndent()
+		break
+	case *ast.AlterTableDropAction:
+		ast.Walk(f, &n.DropKeyword)
+		f.printSpace()
+		if n.TargetKeyword != nil {
+			ast.Walk(f, n.TargetKeyword)
+			f.printSpace()
+		}
+		if len(n.IfExistsKeywords) > 0 {
+			f.printKeywords(n.IfExistsKeywords)
+			f.printSpace()
+		}
+		for i, name := range n.Names {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, name)
+		}
+		break
+	case *ast.AlterTableAlterColumnAction:
+		ast.Walk(f, &n.AlterKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.ColumnKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Column)
+		break
+	case *ast.CreateProcedureStatement:
+		f.printKeywords(n.CreateKeywords)
+		f.printSpace()
+		ast.Walk(f, &n.ProcedureKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		if len(n.Parameters) > 0 {
+			f.increaseIndent()
+			f.printNewLine()
+			f.printRoutineParameters(n.Parameters)
+			f.decreaseIndent()
+		}
+		f.printRoutineOptions(n.WithKeyword, n.Options)
+		f.printNewLine()
+		ast.Walk(f, &n.AsKeyword)
+		for _, s := range n.Statements {
+			f.printNewLine()
+			ast.Walk(f, s)
+		}
+		break
+	case *ast.CreateFunctionStatement:
+		f.printKeywords(n.CreateKeywords)
+		f.printSpace()
+		ast.Walk(f, &n.FunctionKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		if len(n.Parameters) > 0 {
+			f.formattedQuery += " ("
+			f.increaseIndent()
+			f.printNewLine()
+			f.printRoutineParameters(n.Parameters)
+			f.decreaseIndent()
+			f.printNewLine()
+			f.formattedQuery += ")"
+		} else {
+			f.formattedQuery += "()"
+		}
+		f.printNewLine()
+		ast.Walk(f, &n.ReturnsKeyword)
+		f.printSpace()
+		switch n.Type {
+		case ast.FTScalar:
+			ast.Walk(f, n.ReturnType)
+		case ast.FTInlineTable:
+			ast.Walk(f, n.ReturnTableKeyword)
+		case ast.FTMultiStatementTable:
+			ast.Walk(f, n.ReturnVariable)
+		}
+		f.printRoutineOptions(n.WithKeyword, n.Options)
+		if n.AsKeyword != nil {
+			f.printNewLine()
+			ast.Walk(f, n.AsKeyword)
+		}
+		f.printNewLine()
+		if n.Type == ast.FTInlineTable {
+			ast.Walk(f, n.ReturnKeyword)
+			f.printSpace()
+			ast.Walk(f, n.ReturnQuery)
+		} else {
+			ast.Walk(f, n.Body)
+		}
+		break
+	case *ast.RoutineParameter:
+		ast.Walk(f, &n.Name)
+		f.printSpace()
+		if n.AsKeyword != nil {
+			ast.Walk(f, n.AsKeyword)
+			f.printSpace()
+		}
+		if n.DataType != nil {
+			ast.Walk(f, n.DataType)
+		} else {
+			ast.Walk(f, n.TypeName)
+		}
+		if n.Default != nil {
+			f.formattedQuery += " = "
+			ast.Walk(f, n.Default)
+		}
+		if len(n.OptionKeywords) > 0 {
+			f.printSpace()
+			f.printKeywords(n.OptionKeywords)
+		}
+		break
+	case *ast.RoutineOption:
+		f.printKeywords(n.Keywords)
+		if n.Value != nil {
+			f.printSpace()
+			ast.Walk(f, n.Value)
+		}
+		break
+	case *ast.CreateViewStatement:
+		f.printKeywords(n.CreateKeywords)
+		f.printSpace()
+		ast.Walk(f, &n.ViewKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		if n.Columns != nil {
+			f.formattedQuery += " ("
+			ast.Walk(f, n.Columns)
+			f.formattedQuery += ")"
+		}
+		f.printRoutineOptions(n.WithKeyword, n.Options)
+		f.printNewLine()
+		ast.Walk(f, &n.AsKeyword)
+		f.printNewLine()
+		ast.Walk(f, n.Query)
+		break
+	case *ast.CreateIndexStatement:
+		ast.Walk(f, &n.CreateKeyword)
+		f.printSpace()
+		if n.UniqueKeyword != nil {
+			ast.Walk(f, n.UniqueKeyword)
+			f.printSpace()
+		}
+		if n.ClusteredKeyword != nil {
+			ast.Walk(f, n.ClusteredKeyword)
+			f.printSpace()
+		}
+		ast.Walk(f, &n.IndexKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		f.printSpace()
+		ast.Walk(f, &n.OnKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Table)
+		f.formattedQuery += " ("
+		for i := range n.Columns {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, &n.Columns[i])
+		}
+		f.formattedQuery += ")"
+		if n.IncludeKeyword != nil {
+			f.printNewLine()
+			ast.Walk(f, n.IncludeKeyword)
+			f.formattedQuery += " ("
+			ast.Walk(f, n.IncludeColumns)
+			f.formattedQuery += ")"
+		}
+		if n.WhereClause != nil {
+			ast.Walk(f, n.WhereClause)
+		}
+		if n.WithKeyword != nil {
+			f.printNewLine()
+			ast.Walk(f, n.WithKeyword)
+			f.formattedQuery += " ("
+			for i := range n.Options {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, &n.Options[i])
+			}
+			f.formattedQuery += ")"
+		}
+		break
+	case *ast.IndexOption:
+		ast.Walk(f, n.Name)
+		f.formattedQuery += " = "
+		if n.ValueKeyword != nil {
+			ast.Walk(f, n.ValueKeyword)
+		} else {
+			ast.Walk(f, n.Value)
+		}
+		break
+	case *ast.CreateTriggerStatement:
+		f.printKeywords(n.CreateKeywords)
+		f.printSpace()
+		ast.Walk(f, &n.TriggerKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Name)
+		f.printSpace()
+		ast.Walk(f, &n.OnKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Table)
+		f.printRoutineOptions(n.WithKeyword, n.Options)
+		f.printNewLine()
+		f.printKeywords(n.TimingKeywords)
+		f.printSpace()
+		for i := range n.EventKeywords {
+			if i > 0 {
+				f.printExpressionListComma()
 			}
+			ast.Walk(f, &n.EventKeywords[i])
+		}
+		f.printNewLine()
+		ast.Walk(f, &n.AsKeyword)
+		for _, s := range n.Statements {
+			f.printNewLine()
 			ast.Walk(f, s)
 		}
 		break
-	case *ast.SelectStatement:
-		return f
+	case *ast.ColumnDefinition:
+		ast.Walk(f, n.Name)
+		f.printAlignmentSpace(f.columnNameWidth)
+		if n.AsKeyword != nil {
+			ast.Walk(f, n.AsKeyword)
+			f.formattedQuery += " ("
+			ast.Walk(f, n.ComputedValue)
+			f.formattedQuery += ")"
+			if n.PersistedKeyword != nil {
+				f.printSpace()
+				ast.Walk(f, n.PersistedKeyword)
+			}
+		} else {
+			ast.Walk(f, n.DataType)
+		}
+		if n.Identity == nil && len(n.NullKeywords) == 0 && len(n.Constraints) == 0 {
+			break
+		}
+		if n.AsKeyword != nil {
+			f.printSpace()
+		} else {
+			f.printAlignmentSpace(f.columnDataTypeWidth)
+		}
+		if n.Identity != nil {
+			ast.Walk(f, n.Identity)
+			if len(n.NullKeywords) > 0 || len(n.Constraints) > 0 {
+				f.printSpace()
+			}
+		}
+		if len(n.NullKeywords) > 0 {
+			f.printKeywords(n.NullKeywords)
+			if len(n.Constraints) > 0 {
+				f.printSpace()
+			}
+		}
+		for i := range n.Constraints {
+			if i > 0 {
+				f.printSpace()
+			}
+			ast.Walk(f, &n.Constraints[i])
+		}
+		break
+	case *ast.IdentityProperty:
+		ast.Walk(f, &n.IdentityKeyword)
+		if n.Seed != nil {
+			f.formattedQuery += "("
+			ast.Walk(f, n.Seed)
+			f.printExpressionListComma()
+			ast.Walk(f, n.Increment)
+			f.formattedQuery += ")"
+		}
+		break
+	case *ast.TableConstraint:
+		if n.ConstraintKeyword != nil {
+			ast.Walk(f, n.ConstraintKeyword)
+			f.printSpace()
+			ast.Walk(f, n.Name)
+			f.printSpace()
+		}
+		f.printKeywords(n.TypeKeywords)
+		if n.ClusteredKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.ClusteredKeyword)
+		}
+		if len(n.Columns) > 0 {
+			if len(n.TypeKeywords) > 0 {
+				f.printSpace()
+			}
+			f.formattedQuery += "("
+			for i := range n.Columns {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, &n.Columns[i])
+			}
+			f.formattedQuery += ")"
+		}
+		if n.ReferencesKeyword != nil {
+			if len(n.TypeKeywords) > 0 {
+				f.printSpace()
+			}
+			ast.Walk(f, n.ReferencesKeyword)
+			f.printSpace()
+			ast.Walk(f, n.ReferencedTable)
+			if n.ReferencedColumns != nil {
+				f.formattedQuery += " ("
+				ast.Walk(f, n.ReferencedColumns)
+				f.formattedQuery += ")"
+			}
+		}
+		if len(n.OnDeleteKeywords) > 0 {
+			f.printSpace()
+			f.printKeywords(n.OnDeleteKeywords)
+		}
+		if len(n.OnUpdateKeywords) > 0 {
+			f.printSpace()
+			f.printKeywords(n.OnUpdateKeywords)
+		}
+		if n.Type == ast.CTCheck {
+			f.formattedQuery += " ("
+			ast.Walk(f, n.Expression)
+			f.formattedQuery += ")"
+		} else if n.Type == ast.CTDefault {
+			f.printSpace()
+			ast.Walk(f, n.Expression)
+		}
+		if n.ForKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.ForKeyword)
+			f.printSpace()
+			ast.Walk(f, n.ForColumn)
+		}
+		break
+	case *ast.SetLocalVariableStatement:
+		ast.Walk(f, &n.SetKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Assignment)
+		break
+	case *ast.MergeStatement:
+		f.printCommonTableExpressions(n.WithKeyword, n.CTE)
+		ast.Walk(f, &n.MergeKeyword)
+		if n.Top != nil {
+			ast.Walk(f, n.Top)
+		}
+		if n.IntoKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.IntoKeyword)
+		}
+		f.printSpace()
+		ast.Walk(f, n.Target)
+		f.printNewLine()
+		ast.Walk(f, &n.UsingKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Source)
+		f.printSpace()
+		ast.Walk(f, &n.OnKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Condition)
+		for i := range n.WhenClauses {
+			f.printNewLine()
+			ast.Walk(f, &n.WhenClauses[i])
+		}
+		if n.OutputClause != nil {
+			ast.Walk(f, n.OutputClause)
+		}
+		// merge has to be terminated
+		f.formattedQuery += ";"
+		break
+	case *ast.MergeWhenClause:
+		for i, k := range n.WhenKeywords {
+			if i > 0 {
+				f.printSpace()
+			}
+			ast.Walk(f, &k)
+		}
+		if n.AndKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.AndKeyword)
+			f.printSpace()
+			ast.Walk(f, n.Condition)
+		}
+		f.printSpace()
+		ast.Walk(f, &n.ThenKeyword)
+		f.increaseIndent()
+		f.printNewLine()
+		ast.Walk(f, n.Action)
+		f.decreaseIndent()
+		break
+	case *ast.MergeUpdateAction:
+		ast.Walk(f, &n.UpdateKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.SetKeyword)
+		f.printAssignments(n.Assignments)
+		break
+	case *ast.MergeInsertAction:
+		ast.Walk(f, &n.InsertKeyword)
+		if n.Columns != nil {
+			f.printSpace()
+			f.printColumnList(n.Columns)
+		}
+		if n.Values != nil {
+			f.printNewLine()
+			ast.Walk(f, n.Values)
+		} else if n.DefaultValuesKeyword != nil {
+			for _, k := range n.DefaultValuesKeyword {
+				f.printSpace()
+				ast.Walk(f, &k)
+			}
+		}
+		break
+	case *ast.MergeDeleteAction:
+		ast.Walk(f, &n.DeleteKeyword)
+		break
+	case *ast.OutputClause:
+		f.printNewLine()
+		ast.Walk(f, &n.OutputKeyword)
+		ast.Walk(f, &n.Columns)
+		if n.IntoKeyword != nil {
+			f.printNewLine()
+			ast.Walk(f, n.IntoKeyword)
+			f.printSpace()
+			ast.Walk(f, n.IntoTarget)
+			if n.IntoColumns != nil {
+				f.printSpace()
+				f.printColumnList(n.IntoColumns)
+			}
+		}
+		break
+	case *ast.ExecuteStatement:
+		// the arguments go one per line when the call does not fit
+		wrap := f.exceedsMaxWidth(n.TokenLiteral())
+		ast.Walk(f, &n.ExecKeyword)
+		f.printSpace()
+		if n.Command != nil {
+			f.formattedQuery += "("
+			ast.Walk(f, n.Command)
+			f.formattedQuery += ")"
+			break
+		}
+		if n.ReturnStatus != nil {
+			ast.Walk(f, n.ReturnStatus)
+			f.formattedQuery += " = "
+		}
+		ast.Walk(f, n.Name)
+		if wrap {
+			f.increaseIndent()
+		}
+		for i := range n.Arguments {
+			if wrap && i == 0 {
+				f.printNewLine()
+			} else if wrap {
+				f.printSelectColumnComma()
+			} else if i > 0 {
+				f.printExpressionListComma()
+			} else {
+				f.printSpace()
+			}
+			ast.Walk(f, &n.Arguments[i])
+		}
+		if wrap {
+			f.decreaseIndent()
+		}
+		break
+	case *ast.ExecuteArgument:
+		if n.Name != nil {
+			ast.Walk(f, n.Name)
+			f.formattedQuery += " = "
+		}
+		if n.DefaultKeyword != nil {
+			ast.Walk(f, n.DefaultKeyword)
+		} else {
+			ast.Walk(f, n.Value)
+		}
+		if n.OutputKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.OutputKeyword)
+		}
+		break
+	case *ast.TableValueConstructor:
+		ast.Walk(f, &n.ValuesKeyword)
+		if len(n.Rows) > 1 {
+			f.increaseIndent()
+			f.printNewLine()
+		} else {
+			f.printSpace()
+		}
+		for i := range n.Rows {
+			if i > 0 {
+				f.printSelectColumnComma()
+				f.printQueuedChars()
+			}
+			f.formattedQuery += "("
+			ast.Walk(f, &n.Rows[i])
+			f.formattedQuery += ")"
+		}
+		if len(n.Rows) > 1 {
+			f.decreaseIndent()
+		}
+		break
 	case *ast.SelectBody:
 		ast.Walk(f, &n.SelectKeyword)
 		if n.AllKeyword != nil {
@@ -105,26 +988,43 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(f, n.Top)
 		}
 		ast.Walk(f, &n.SelectItems)
-		ast.Walk(f, n.Table)
+		if n.IntoClause != nil {
+			ast.Walk(f, n.IntoClause)
+		}
+		if n.Table != nil {
+			ast.Walk(f, n.Table)
+		}
 		if n.WhereClause != nil {
 			ast.Walk(f, n.WhereClause)
 		}
-		if n.HavingClause != nil {
-			ast.Walk(f, n.HavingClause)
-		}
 		if n.GroupByClause != nil {
 			ast.Walk(f, n.GroupByClause)
 		}
+		if n.HavingClause != nil {
+			ast.Walk(f, n.HavingClause)
+		}
 		if n.OrderByClause != nil {
 			ast.Walk(f, n.OrderByClause)
 		}
+		if n.ForClause != nil {
+			ast.Walk(f, n.ForClause)
+		}
+		if n.OptionClause != nil {
+			ast.Walk(f, n.OptionClause)
+		}
 		break
 	case *ast.ExprStringLiteral:
+		if n.Unicode {
+			f.formattedQuery += "N"
+		}
 		f.formattedQuery += fmt.Sprintf("'%s'", n.Value)
 		break
 	case *ast.ExprNumberLiteral:
 		f.formattedQuery += n.Value
 		break
+	case *ast.ExprNullLiteral:
+		f.printKeyword("NULL")
+		break
 	case *ast.ExprLocalVariable:
 		f.formattedQuery += fmt.Sprintf("@%s", n.Value)
 		break
@@ -157,6 +1057,9 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 	case *ast.ExprBuiltInFunctionName:
 		f.printKeyword(n.Value)
 		break
+	case *ast.ExprDatePart:
+		f.printKeyword(n.Value)
+		break
 	case *ast.SelectItems:
 		if len(n.Items) > 1 {
 			f.increaseIndent()
@@ -180,33 +1083,196 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		f.printSpace()
 		ast.Walk(f, n.Clause)
 		break
-	case *ast.HavingClause:
+	case *ast.HavingClause:
+		f.printNewLine()
+		ast.Walk(f, &n.HavingKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Clause)
+		break
+	case *ast.IntoClause:
+		f.printNewLine()
+		ast.Walk(f, &n.IntoKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Target)
+		break
+	case *ast.GroupByClause:
+		f.printNewLine()
+		for _, k := range n.GroupByKeyword {
+			ast.Walk(f, &k)
+			f.printSpace()
+		}
+		for i, e := range n.Items {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, e)
+		}
+		break
+	case *ast.ExprGrouping:
+		for _, k := range n.Keywords {
+			ast.Walk(f, &k)
+			f.printSpace()
+		}
+		f.printInList(n.Sets)
+		break
+	case *ast.ExprGroupingSet:
+		f.formattedQuery += "("
+		for i, e := range n.Items {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, e)
+		}
+		f.formattedQuery += ")"
+		break
+	case *ast.TableArg:
+		f.printNewLine()
+		ast.Walk(f, &n.FromKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Table)
+		for _, j := range n.Joins {
+			ast.Walk(f, &j)
+		}
+		break
+	case *ast.TableSource:
+		ast.Walk(f, n.Source)
+		if n.Columns != nil {
+			f.formattedQuery += "("
+			ast.Walk(f, n.Columns)
+			f.formattedQuery += ")"
+		}
+		if n.WithKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.WithKeyword)
+			f.formattedQuery += " ("
+			for i := range n.Hints {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, &n.Hints[i])
+			}
+			f.formattedQuery += ")"
+		}
+		break
+	case *ast.TableHint:
+		ast.Walk(f, &n.HintKeyword)
+		if len(n.Indexes) > 0 {
+			f.formattedQuery += "("
+			for i, e := range n.Indexes {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, e)
+			}
+			if n.Columns != nil {
+				f.formattedQuery += " ("
+				ast.Walk(f, n.Columns)
+				f.formattedQuery += ")"
+			}
+			f.formattedQuery += ")"
+		}
+		break
+	case *ast.ForClause:
+		f.printNewLine()
+		ast.Walk(f, &n.ForKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.ModeKeyword)
+		for i := range n.Options {
+			if i > 0 {
+				f.formattedQuery += ","
+			}
+			f.printSpace()
+			ast.Walk(f, &n.Options[i])
+		}
+		break
+	case *ast.ForOption:
+		f.printKeywords(n.Keywords)
+		if n.Value != nil {
+			f.formattedQuery += "("
+			ast.Walk(f, n.Value)
+			f.formattedQuery += ")"
+		}
+		break
+	case *ast.ExprRowsetFunction:
+		ast.Walk(f, &n.FunctionKeyword)
+		f.formattedQuery += "("
+		for i, e := range n.Args {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, e)
+		}
+		f.formattedQuery += ")"
+		if n.WithKeyword == nil {
+			break
+		}
+		f.printSpace()
+		ast.Walk(f, n.WithKeyword)
+		f.printSpace()
+		if n.SchemaTable != nil {
+			ast.Walk(f, n.SchemaTable)
+			break
+		}
+		f.formattedQuery += "("
+		f.increaseIndent()
+		f.printNewLine()
+		f.printRowsetColumns(n.Columns)
+		f.decreaseIndent()
+		f.printNewLine()
+		f.formattedQuery += ")"
+		break
+	case *ast.RowsetColumn:
+		ast.Walk(f, n.Name)
+		f.printAlignmentSpace(f.columnNameWidth)
+		ast.Walk(f, &n.DataType)
+		if n.Path != nil {
+			f.printAlignmentSpace(f.columnDataTypeWidth)
+			ast.Walk(f, n.Path)
+		}
+		if n.AsJsonKeyword != nil {
+			f.printSpace()
+			f.printKeywords(n.AsJsonKeyword[:])
+		}
+		break
+	case *ast.OptionClause:
 		f.printNewLine()
-		ast.Walk(f, &n.HavingKeyword)
-		f.printSpace()
-		ast.Walk(f, n.Clause)
+		ast.Walk(f, &n.OptionKeyword)
+		f.formattedQuery += " ("
+		for i := range n.Hints {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, &n.Hints[i])
+		}
+		f.formattedQuery += ")"
 		break
-	case *ast.GroupByClause:
-		f.printNewLine()
-		for _, k := range n.GroupByKeyword {
-			ast.Walk(f, &k)
+	case *ast.QueryHint:
+		f.printKeywords(n.Keywords)
+		if n.Value != nil {
 			f.printSpace()
+			ast.Walk(f, n.Value)
 		}
-		for _, e := range n.Items {
-			ast.Walk(f, e)
+		if len(n.Variables) > 0 {
+			f.formattedQuery += " ("
+			for i := range n.Variables {
+				if i > 0 {
+					f.printExpressionListComma()
+				}
+				ast.Walk(f, &n.Variables[i])
+			}
+			f.formattedQuery += ")"
 		}
 		break
-	case *ast.TableArg:
-		f.printNewLine()
-		ast.Walk(f, &n.FromKeyword)
-		f.printSpace()
-		ast.Walk(f, n.Table)
-		for _, j := range n.Joins {
-			ast.Walk(f, &j)
+	case *ast.OptimizeForVariable:
+		ast.Walk(f, &n.Variable)
+		if n.UnknownKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.UnknownKeyword)
+		} else {
+			f.formattedQuery += " = "
+			ast.Walk(f, n.Value)
 		}
 		break
-	case *ast.TableSource:
-		return f
 	case *ast.Join:
 		f.printNewLine()
 		for _, k := range n.JoinTypeKeyword {
@@ -214,12 +1280,13 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 			f.printSpace()
 		}
 		ast.Walk(f, n.Table)
-		f.printSpace()
+		// applies have no ON condition
 		if n.OnKeyword != nil {
-			ast.Walk(f, n.OnKeyword)
 			f.printSpace()
+			ast.Walk(f, n.OnKeyword)
 		}
 		if n.Condition != nil {
+			f.printSpace()
 			ast.Walk(f, n.Condition)
 		}
 		break
@@ -227,7 +1294,13 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		f.printSpace()
 		ast.Walk(f, &n.TopKeyword)
 		f.printSpace()
-		ast.Walk(f, n.Quantity)
+		if n.Parenthesized {
+			f.formattedQuery += "("
+			ast.Walk(f, n.Quantity)
+			f.formattedQuery += ")"
+		} else {
+			ast.Walk(f, n.Quantity)
+		}
 		if n.PercentKeyword != nil {
 			f.printSpace()
 			ast.Walk(f, n.PercentKeyword)
@@ -303,7 +1376,20 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		f.increaseIndent()
 		f.printNewLine()
 
-		ast.Walk(f, &n.SelectBody)
+		ast.Walk(f, n.Query)
+
+		f.decreaseIndent()
+		f.printNewLine()
+		f.decreaseIndent()
+		f.formattedQuery += ")"
+		break
+	case *ast.ExprValuesTable:
+		f.formattedQuery += "("
+		f.increaseIndent()
+		f.increaseIndent()
+		f.printNewLine()
+
+		ast.Walk(f, &n.TableValueConstructor)
 
 		f.decreaseIndent()
 		f.printNewLine()
@@ -421,10 +1507,27 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 			ast.Walk(f, a)
 		}
 		f.formattedQuery += ")"
+		if n.WithinGroupClause != nil {
+			ast.Walk(f, n.WithinGroupClause)
+		}
 		if n.OverClause != nil {
 			ast.Walk(f, n.OverClause)
 		}
 		break
+	case *ast.WithinGroupClause:
+		f.printSpace()
+		f.printKeywords(n.WithinGroupKeyword[:])
+		f.formattedQuery += " ("
+		f.printKeywords(n.OrderByKeyword[:])
+		f.printSpace()
+		for i := range n.OrderByClause {
+			if i > 0 {
+				f.printExpressionListComma()
+			}
+			ast.Walk(f, &n.OrderByClause[i])
+		}
+		f.formattedQuery += ")"
+		break
 	case *ast.ExprCast:
 		ast.Walk(f, &n.CastKeyword)
 		f.formattedQuery += "("
@@ -435,19 +1538,92 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		ast.Walk(f, &n.DataType)
 		f.formattedQuery += ")"
 		break
+	case *ast.ExprConvert:
+		ast.Walk(f, &n.ConvertKeyword)
+		f.formattedQuery += "("
+		ast.Walk(f, &n.DataType)
+		f.printExpressionListComma()
+		ast.Walk(f, n.Expression)
+		if n.Style != nil {
+			f.printExpressionListComma()
+			ast.Walk(f, n.Style)
+		}
+		f.formattedQuery += ")"
+		break
+	case *ast.ExprParse:
+		ast.Walk(f, &n.ParseKeyword)
+		f.formattedQuery += "("
+		ast.Walk(f, n.Expression)
+		f.printSpace()
+		ast.Walk(f, &n.AsKeyword)
+		f.printSpace()
+		ast.Walk(f, &n.DataType)
+		if n.UsingKeyword != nil {
+			f.printSpace()
+			ast.Walk(f, n.UsingKeyword)
+			f.printSpace()
+			ast.Walk(f, n.Culture)
+		}
+		f.formattedQuery += ")"
+		break
+	case *ast.ExprCase:
+		// a case after other text on the line is indented like a subquery from
+		// the start of that line
+		indentLevel := f.indentLevel
+		if !f.atLineStart() {
+			f.indentLevel = f.lineIndentLevel() + 1
+		}
+		ast.Walk(f, &n.CaseKeyword)
+		if n.InputExpression != nil {
+			f.printSpace()
+			ast.Walk(f, n.InputExpression)
+		}
+		f.increaseIndent()
+		for i := range n.WhenClauses {
+			f.printNewLine()
+			ast.Walk(f, &n.WhenClauses[i])
+		}
+		if n.ElseClause != nil {
+			f.printNewLine()
+			ast.Walk(f, n.ElseClause)
+		}
+		f.decreaseIndent()
+		f.printNewLine()
+		ast.Walk(f, &n.EndKeyword)
+		f.indentLevel = indentLevel
+		break
+	case *ast.WhenClause:
+		ast.Walk(f, &n.WhenKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Condition)
+		f.printSpace()
+		ast.Walk(f, &n.ThenKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Result)
+		break
+	case *ast.ElseClause:
+		ast.Walk(f, &n.ElseKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Result)
+		break
 	case *ast.CommonTableExpression:
 		f.formattedQuery += n.Name
 		if n.Columns != nil {
 			f.printSpace()
+			f.formattedQuery += "("
 			ast.Walk(f, n.Columns)
+			f.formattedQuery += ")"
 		}
-		f.printNewLine()
+		f.printSpace()
 		ast.Walk(f, &n.AsKeyword)
+		f.printSpace()
 		f.formattedQuery += "("
 		f.increaseIndent()
-		ast.Walk(f, &n.Query)
-		f.formattedQuery += ")"
+		f.printNewLine()
+		ast.Walk(f, n.Query)
 		f.decreaseIndent()
+		f.printNewLine()
+		f.formattedQuery += ")"
 		break
 	case *ast.DataType:
 		switch n.Kind {
@@ -477,18 +1653,32 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		case ast.DTDecimal:
 			f.printKeyword("DECIMAL")
 			if n.DecimalNumericSize != nil {
+				f.formattedQuery += "("
 				ast.Walk(f, n.DecimalNumericSize)
+				f.formattedQuery += ")"
 			}
 		case ast.DTNumeric:
 			f.printKeyword("NUMERIC")
 			if n.DecimalNumericSize != nil {
+				f.formattedQuery += "("
 				ast.Walk(f, n.DecimalNumericSize)
+				f.formattedQuery += ")"
 			}
 		case ast.DTVarchar:
 			f.printKeyword("VARCHAR")
-			if n.VarcharLength != nil {
-				f.formattedQuery += fmt.Sprintf("(%d)", *n.VarcharLength)
-			}
+			f.printDataTypeLength(n)
+		case ast.DTChar:
+			f.printKeyword("CHAR")
+			f.printDataTypeLength(n)
+		case ast.DTNchar:
+			f.printKeyword("NCHAR")
+			f.printDataTypeLength(n)
+		case ast.DTNvarchar:
+			f.printKeyword("NVARCHAR")
+			f.printDataTypeLength(n)
+		case ast.DTVarbinary:
+			f.printKeyword("VARBINARY")
+			f.printDataTypeLength(n)
 		}
 		break
 	case *ast.NumericSize:
@@ -515,6 +1705,13 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 		f.printSpace()
 		ast.Walk(f, n.Right)
 		break
+	case *ast.ExprAssignmentOperator:
+		ast.Walk(f, n.Left)
+		f.printSpace()
+		f.formattedQuery += n.Operator.TokenLiteral()
+		f.printSpace()
+		ast.Walk(f, n.Right)
+		break
 	case *ast.ExprAndLogicalOperator:
 		ast.Walk(f, n.Left)
 		f.increaseIndent()
@@ -580,23 +1777,22 @@ func (f *Formatter) Visit(node ast.Node) ast.Visitor {
 			f.printSpace()
 		}
 
-		f.formattedQuery += "("
-		for i, e := range n.Expressions {
-			if i == 0 && f.settings.IndentInLists {
-				f.increaseIndent()
-				f.increaseIndent()
-				f.printNewLine()
-			}
-			if i > 0 {
-				f.printInListComma()
-			}
-			ast.Walk(f, e)
-		}
-		if f.settings.IndentInLists {
-			f.decreaseIndent()
-			f.printNewLine()
-			f.decreaseIndent()
-		}
+		f.printInList(n.Expressions)
+		break
+	case *ast.ExprPivot:
+		ast.Walk(f, n.Source)
+		f.printNewLine()
+		ast.Walk(f, &n.PivotKeyword)
+		f.formattedQuery += " ("
+		ast.Walk(f, n.Aggregate)
+		f.printSpace()
+		ast.Walk(f, &n.ForKeyword)
+		f.printSpace()
+		ast.Walk(f, n.Column)
+		f.printSpace()
+		ast.Walk(f, &n.InKeyword)
+		f.printSpace()
+		f.printInList(n.Values)
 		f.formattedQuery += ")"
 		break
 	case *ast.ExprLikeLogicalOperator:
@@ -672,6 +1868,274 @@ func nodeList(n ast.Node) []ast.Node {
 	return list
 }
 
+func (f *Formatter) printCommonTableExpressions(withKeyword *ast.Keyword, ctes *[]ast.CommonTableExpression) {
+	if ctes == nil {
+		return
+	}
+
+	ast.Walk(f, withKeyword)
+	f.printSpace()
+	for i := range *ctes {
+		if i > 0 {
+			f.formattedQuery += ","
+			f.printNewLine()
+		}
+		ast.Walk(f, &(*ctes)[i])
+	}
+	f.printNewLine()
+}
+
+// prints the statements of a BEGIN...END style block one level deeper
+func (f *Formatter) printBlockStatements(statements []ast.Statement) {
+	f.increaseIndent()
+	for i, s := range statements {
+		f.printNewLine()
+		ast.Walk(f, s)
+		f.printPrecedingTerminator(statements, i)
+	}
+	f.decreaseIndent()
+}
+
+// ends the statement with a semicolon when the next one needs it, merge is
+// always terminated
+func (f *Formatter) printPrecedingTerminator(statements []ast.Statement, i int) {
+	if i+1 >= len(statements) {
+		return
+	}
+	if _, ok := statements[i].(*ast.MergeStatement); ok {
+		return
+	}
+	if needsPrecedingTerminator(statements[i+1]) || endsWithBareReturn(statements[i]) {
+		f.formattedQuery += ";"
+	}
+}
+
+func startsWithParenthesis(query ast.QueryExpression) bool {
+	switch n := query.(type) {
+	case *ast.ParenthesizedQuery:
+		return true
+	case *ast.SetOperation:
+		return startsWithParenthesis(n.Left)
+	}
+	return false
+}
+
+// a RETURN without a value would take the next statement as its value
+func endsWithBareReturn(statement ast.Statement) bool {
+	switch n := statement.(type) {
+	case *ast.ReturnStatement:
+		return n.Value == nil
+	case *ast.IfStatement:
+		if n.Else != nil {
+			return endsWithBareReturn(n.Else)
+		}
+		return endsWithBareReturn(n.Then)
+	case *ast.WhileStatement:
+		return endsWithBareReturn(n.Body)
+	}
+	return false
+}
+
+// statements that sql server reads as part of the statement before them when
+// it is not terminated
+func needsPrecedingTerminator(statement ast.Statement) bool {
+	switch n := statement.(type) {
+	case *ast.ThrowStatement:
+		return true
+	case *ast.SelectStatement:
+		return n.CTE != nil || startsWithParenthesis(n.Query)
+	case *ast.InsertStatement:
+		return n.CTE != nil
+	case *ast.UpdateStatement:
+		return n.CTE != nil
+	case *ast.DeleteStatement:
+		return n.CTE != nil
+	case *ast.MergeStatement:
+		return n.CTE != nil
+	}
+	return false
+}
+
+func (f *Formatter) printKeywords(keywords []ast.Keyword) {
+	for i := range keywords {
+		if i > 0 {
+			f.printSpace()
+		}
+		ast.Walk(f, &keywords[i])
+	}
+}
+
+// prints the body of an if or while, blocks stay at the current level and
+// single statements are indented
+func (f *Formatter) printStatementBody(body ast.Statement) {
+	if _, ok := body.(*ast.BlockStatement); ok {
+		f.printNewLine()
+		ast.Walk(f, body)
+		return
+	}
+
+	f.increaseIndent()
+	f.printNewLine()
+	ast.Walk(f, body)
+	f.decreaseIndent()
+}
+
+func (f *Formatter) printRoutineParameters(parameters []ast.RoutineParameter) {
+	for i := range parameters {
+		if i > 0 {
+			f.printSelectColumnComma()
+		}
+		ast.Walk(f, &parameters[i])
+	}
+}
+
+func (f *Formatter) printRoutineOptions(withKeyword *ast.Keyword, options []ast.RoutineOption) {
+	if withKeyword == nil {
+		return
+	}
+
+	f.printNewLine()
+	ast.Walk(f, withKeyword)
+	f.printSpace()
+	for i := range options {
+		if i > 0 {
+			f.printExpressionListComma()
+		}
+		ast.Walk(f, &options[i])
+	}
+}
+
+func (f *Formatter) printParenthesizedTableElements(definitions []ast.TableElement) {
+	f.formattedQuery += "("
+	f.increaseIndent()
+	f.printNewLine()
+	f.printTableElements(definitions)
+	f.decreaseIndent()
+	f.printNewLine()
+	f.formattedQuery += ")"
+}
+
+// prints one definition per line with the data types of the columns lined up
+func (f *Formatter) printTableElements(definitions []ast.TableElement) {
+	commaWidth := 0
+	if f.settings.IndentCommaLists == ICLNoSpaceAfterComma {
+		commaWidth = 1
+	} else if f.settings.IndentCommaLists == ICLSpaceAfterComma {
+		commaWidth = 2
+	}
+
+	nameWidth, dataTypeWidth := 0, 0
+	for i, d := range definitions {
+		column, ok := d.(*ast.ColumnDefinition)
+		if !ok {
+			continue
+		}
+		width := len(column.Name.TokenLiteral())
+		if i > 0 {
+			width += commaWidth
+		}
+		nameWidth = max(nameWidth, width)
+		if column.DataType != nil {
+			dataTypeWidth = max(dataTypeWidth, len(column.DataType.TokenLiteral()))
+		}
+	}
+
+	f.columnNameWidth = f.currentLineWidth() + nameWidth + 1
+	f.columnDataTypeWidth = f.columnNameWidth + dataTypeWidth + 1
+	for i, d := range definitions {
+		if i > 0 {
+			f.printSelectColumnComma()
+		}
+		ast.Walk(f, d)
+	}
+	f.columnNameWidth = 0
+	f.columnDataTypeWidth = 0
+}
+
+// prints the WITH columns of OPENJSON or OPENXML lined up like table columns
+func (f *Formatter) printRowsetColumns(columns []ast.RowsetColumn) {
+	commaWidth := 0
+	if f.settings.IndentCommaLists == ICLNoSpaceAfterComma {
+		commaWidth = 1
+	} else if f.settings.IndentCommaLists == ICLSpaceAfterComma {
+		commaWidth = 2
+	}
+
+	nameWidth, dataTypeWidth := 0, 0
+	for i, c := range columns {
+		width := len(c.Name.TokenLiteral())
+		if i > 0 {
+			width += commaWidth
+		}
+		nameWidth = max(nameWidth, width)
+		dataTypeWidth = max(dataTypeWidth, len(c.DataType.TokenLiteral()))
+	}
+
+	f.columnNameWidth = f.currentLineWidth() + nameWidth + 1
+	f.columnDataTypeWidth = f.columnNameWidth + dataTypeWidth + 1
+	for i := range columns {
+		if i > 0 {
+			f.printSelectColumnComma()
+		}
+		ast.Walk(f, &columns[i])
+	}
+	f.columnNameWidth = 0
+	f.columnDataTypeWidth = 0
+}
+
+// pads the current line up to width, or prints a single space when it is already past it
+func (f *Formatter) printAlignmentSpace(width int) {
+	f.printSpace()
+	for f.currentLineWidth() < width {
+		f.printSpace()
+	}
+}
+
+// prints the column list of an insert, one column per line
+func (f *Formatter) printColumnList(columns *ast.ExprExpressionList) {
+	f.formattedQuery += "("
+	f.increaseIndent()
+	f.printNewLine()
+	for i, e := range columns.List {
+		if i > 0 {
+			f.printSelectColumnComma()
+		}
+		ast.Walk(f, e)
+	}
+	f.decreaseIndent()
+	f.printNewLine()
+	f.formattedQuery += ")"
+}
+
+// prints the assignments after SET, one per line when there is more than one
+func (f *Formatter) printAssignments(assignments []*ast.ExprAssignmentOperator) {
+	if len(assignments) > 1 {
+		f.increaseIndent()
+		f.printNewLine()
+	} else {
+		f.printSpace()
+	}
+	for i, e := range assignments {
+		if i > 0 {
+			f.printSelectColumnComma()
+		}
+		ast.Walk(f, e)
+	}
+	if len(assignments) > 1 {
+		f.decreaseIndent()
+	}
+}
+
+func (f *Formatter) printDataTypeLength(dataType *ast.DataType) {
+	if dataType.MaxLength {
+		f.formattedQuery += "("
+		f.printKeyword("MAX")
+		f.formattedQuery += ")"
+	} else if dataType.VarcharLength != nil {
+		f.formattedQuery += fmt.Sprintf("(%d)", *dataType.VarcharLength)
+	}
+}
+
 func (f *Formatter) increaseIndent() {
 	f.indentLevel += 1
 }
@@ -710,6 +2174,33 @@ func (f *Formatter) printNewLine() {
 	f.printIndent()
 }
 
+// whether text printed at the end of the current line would go past the max width
+func (f *Formatter) exceedsMaxWidth(text string) bool {
+	if f.settings.MaxWidth == 0 {
+		return false
+	}
+	return f.currentLineWidth()+len(text) > int(f.settings.MaxWidth)
+}
+
+// whether only indentation and a list comma have been printed on the current line
+func (f *Formatter) atLineStart() bool {
+	line := f.formattedQuery[strings.LastIndex(f.formattedQuery, "\n")+1:]
+	return strings.Trim(line, " \t,") == ""
+}
+
+func (f *Formatter) lineIndentLevel() uint32 {
+	if f.settings.IndentWidth == 0 {
+		return 0
+	}
+	line := f.formattedQuery[strings.LastIndex(f.formattedQuery, "\n")+1:]
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	return uint32(indent) / f.settings.IndentWidth
+}
+
+func (f *Formatter) currentLineWidth() int {
+	return len(f.formattedQuery) - strings.LastIndex(f.formattedQuery, "\n") - 1
+}
+
 func (f *Formatter) printSelectColumnComma() {
 	if f.settings.IndentCommaLists == ICLNoSpaceAfterComma {
 		f.printNewLine()
@@ -727,6 +2218,28 @@ func (f *Formatter) printExpressionListComma() {
 	f.formattedQuery += ", "
 }
 
+// prints the parenthesized values of an IN, one per line when IndentInLists is set
+func (f *Formatter) printInList(expressions []ast.Expression) {
+	f.formattedQuery += "("
+	for i, e := range expressions {
+		if i == 0 && f.settings.IndentInLists {
+			f.increaseIndent()
+			f.increaseIndent()
+			f.printNewLine()
+		}
+		if i > 0 {
+			f.printInListComma()
+		}
+		ast.Walk(f, e)
+	}
+	if f.settings.IndentInLists {
+		f.decreaseIndent()
+		f.printNewLine()
+		f.decreaseIndent()
+	}
+	f.formattedQuery += ")"
+}
+
 func (f *Formatter) printInListComma() {
 	if f.settings.IndentInLists {
 		f.printSelectColumnComma()
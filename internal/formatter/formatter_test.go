@@ -61,6 +61,844 @@ ORDER BY Symbol DESC`
 	test(t, expected, input)
 }
 
+func TestFormatCaseExpression(t *testing.T) {
+	expected := `SELECT
+    Symbol
+    ,CASE
+        WHEN LastPrice < 10 THEN 'cheap'
+        WHEN LastPrice < 100 THEN 'fair'
+        ELSE 'expensive'
+    END AS PriceRange
+    ,CASE Exchange
+        WHEN 'N' THEN 'NYSE'
+        ELSE 'OTHER'
+    END
+FROM MarketTable`
+
+	input := "select Symbol, case when LastPrice < 10 then 'cheap' when LastPrice < 100 then 'fair'"
+	input += " else 'expensive' end as PriceRange, case Exchange when 'N' then 'NYSE' else 'OTHER' end"
+	input += " from MarketTable"
+
+	test(t, expected, input)
+
+	expected = `SELECT SUM(CASE
+        WHEN LastPrice < 10 THEN 1
+        ELSE 0
+    END)
+FROM MarketTable
+WHERE CASE Exchange
+        WHEN 'N' THEN 1
+    END = 1
+ORDER BY CASE
+        WHEN Symbol = 'SPY' THEN 0
+        ELSE 1
+    END, 
+    Symbol`
+
+	input = "select sum(case when LastPrice < 10 then 1 else 0 end) from MarketTable"
+	input += " where case Exchange when 'N' then 1 end = 1"
+	input += " order by case when Symbol = 'SPY' then 0 else 1 end, Symbol"
+
+	test(t, expected, input)
+}
+
+func TestFormatSetOperation(t *testing.T) {
+	expected := `WITH Tickers AS (
+    SELECT Symbol
+    FROM MarketSymbols
+    UNION ALL
+    SELECT Symbol
+    FROM IndexSymbols
+)
+(
+    SELECT Symbol
+    FROM Tickers
+    EXCEPT
+    SELECT Symbol
+    FROM Delisted
+)
+UNION
+SELECT Symbol
+FROM Watchlist
+ORDER BY Symbol`
+
+	input := "with Tickers as (select Symbol from MarketSymbols union all select Symbol from IndexSymbols)"
+	input += " (select Symbol from Tickers except select Symbol from Delisted) union"
+	input += " select Symbol from Watchlist order by Symbol"
+
+	test(t, expected, input)
+
+	expected = `(
+    SELECT Symbol
+    FROM Watchlist
+)
+ORDER BY Symbol
+OPTION (RECOMPILE)`
+
+	input = "(select Symbol from Watchlist) order by Symbol option (recompile)"
+
+	test(t, expected, input)
+}
+
+func TestFormatApplyOperators(t *testing.T) {
+	expected := `SELECT
+    s.Symbol
+    ,q.Price
+FROM Stocks s
+CROSS APPLY dbo.fn_Splits(s.Symbol) sp
+OUTER APPLY (
+        SELECT TOP 1 Price
+        FROM Quotes
+        WHERE Symbol = s.Symbol
+    ) AS q
+INNER JOIN Delisted d ON d.Symbol = s.Symbol`
+
+	input := "select s.Symbol, q.Price from Stocks s cross apply dbo.fn_Splits(s.Symbol) sp"
+	input += " outer apply (select top 1 Price from Quotes where Symbol = s.Symbol) as q"
+	input += " inner join Delisted d on d.Symbol = s.Symbol"
+
+	test(t, expected, input)
+}
+
+func TestFormatPivotOperators(t *testing.T) {
+	expected := `SELECT
+    p.Symbol
+    ,p.[Jan]
+    ,p.[Feb]
+FROM Sales
+PIVOT (SUM(Amount) FOR [Month] IN (
+        [Jan]
+        ,[Feb]
+    )) AS p`
+
+	input := "select p.Symbol, p.[Jan], p.[Feb] from Sales pivot (sum(Amount) for [Month] in ([Jan], [Feb])) as p"
+
+	test(t, expected, input)
+
+	expected = `SELECT
+    Symbol
+    ,[Month]
+    ,Amount
+FROM MonthlySales
+UNPIVOT (Amount FOR [Month] IN (
+        [Jan]
+        ,[Feb]
+    )) AS u`
+
+	input = "select Symbol, [Month], Amount from MonthlySales unpivot (Amount for [Month] in ([Jan], [Feb])) as u"
+
+	test(t, expected, input)
+
+	expected = `SELECT
+    p.Symbol
+    ,p.[Jan]
+FROM Sales
+PIVOT (SUM(Amount) FOR month IN (
+        [Jan]
+    )) AS p
+UNPIVOT (value FOR month IN (
+        [Jan]
+    )) AS u`
+
+	input = "select p.Symbol, p.[Jan] from Sales pivot (sum(Amount) for month in ([Jan])) as p"
+	input += " unpivot (value for month in ([Jan])) as u"
+
+	test(t, expected, input)
+}
+
+func TestFormatTableAndQueryHints(t *testing.T) {
+	expected := `SELECT
+    q.Symbol
+    ,i.Price
+FROM Quotes q WITH (NOLOCK, INDEX(IX_Quotes_Symbol), FORCESEEK)
+INNER JOIN Indexes i WITH (INDEX(IX_Indexes)) ON i.Symbol = q.Symbol
+ORDER BY q.Symbol
+OPTION (RECOMPILE, MAXDOP 1, OPTIMIZE FOR (@Symbol UNKNOWN, @Rows = 10))`
+
+	input := "select q.Symbol, i.Price from Quotes q with (nolock, Index(IX_Quotes_Symbol), ForceSeek)"
+	input += " inner join Indexes i with (index = IX_Indexes) on i.Symbol = q.Symbol order by q.Symbol"
+	input += " option (Recompile, maxdop 1, optimize for (@Symbol unknown, @Rows = 10))"
+
+	test(t, expected, input)
+
+	expected = `UPDATE Quotes WITH (ROWLOCK, READUNCOMMITTED)
+SET Price = 11
+WHERE Symbol = 'AAL'
+
+DELETE FROM Quotes WITH (FORCESEEK(IX_Quotes (Symbol, TradeDate)), NOWAIT)
+WHERE Price = 0
+
+MERGE INTO Quotes WITH (HOLDLOCK) AS t
+USING Staging s ON t.Symbol = s.Symbol
+WHEN MATCHED THEN
+    DELETE;`
+
+	input = "update Quotes with (rowlock, readuncommitted) set Price = 11 where Symbol = 'AAL';"
+	input += " delete from Quotes with (forceseek(IX_Quotes (Symbol, TradeDate)), nowait) where Price = 0;"
+	input += " merge into Quotes with (holdlock) as t using Staging s on t.Symbol = s.Symbol when matched then delete;"
+
+	test(t, expected, input)
+}
+
+func TestFormatSelectInto(t *testing.T) {
+	expected := `SELECT
+    Symbol
+    ,MAX(Price) AS MaxPrice
+INTO #MaxPrices
+FROM ##Quotes
+GROUP BY Symbol`
+
+	input := "select Symbol, max(Price) as MaxPrice into #MaxPrices from ##Quotes group by Symbol"
+
+	test(t, expected, input)
+}
+
+func TestFormatDerivedTableColumns(t *testing.T) {
+	expected := `SELECT
+    v.id
+    ,d.Price
+FROM (
+        VALUES
+            (1, 'AAL')
+            ,(2, 'AMZN')
+    ) AS v(id, Symbol)
+INNER JOIN (
+        SELECT
+            Symbol
+            ,LastPrice
+        FROM MarketData
+    ) AS d(Symbol, Price) ON d.Symbol = v.Symbol`
+
+	input := "select v.id, d.Price from (values (1, 'AAL'), (2, 'AMZN')) as v(id, Symbol)"
+	input += " inner join (select Symbol, LastPrice from MarketData) as d(Symbol, Price) on d.Symbol = v.Symbol"
+
+	test(t, expected, input)
+}
+
+func TestFormatGroupingSets(t *testing.T) {
+	expected := `SELECT
+    Symbol
+    ,Exchange
+    ,GROUPING(Exchange) AS IsTotal
+    ,SUM(Volume) AS Volume
+FROM Trades
+GROUP BY ROLLUP (
+        Symbol
+        ,Exchange
+    )
+
+SELECT
+    Symbol
+    ,Exchange
+FROM Trades
+GROUP BY Symbol, GROUPING SETS (
+        (Exchange, Venue)
+        ,()
+    )`
+
+	input := "select Symbol, Exchange, grouping(Exchange) as IsTotal, sum(Volume) as Volume from Trades"
+	input += " group by rollup (Symbol, Exchange);"
+	input += " select Symbol, Exchange from Trades group by Symbol, grouping sets ((Exchange, Venue), ())"
+
+	test(t, expected, input)
+}
+
+func TestFormatForClauseAndRowsetFunctions(t *testing.T) {
+	expected := `SELECT STUFF((
+        SELECT ',' + Symbol
+        FROM Quotes
+        ORDER BY Symbol
+        FOR XML PATH('')
+    ), 1, 1, '') AS Symbols
+
+SELECT
+    Symbol
+    ,Price
+FROM Quotes
+FOR JSON PATH, ROOT('data'), INCLUDE_NULL_VALUES
+
+SELECT
+    j.id
+    ,j.name
+FROM OPENJSON(@json, '$.items') WITH (
+    id    INT           '$.id'
+    ,name NVARCHAR(50)  '$.name'
+    ,tags NVARCHAR(MAX) '$.tags' AS JSON
+) AS j`
+
+	input := "select STUFF((select ',' + Symbol from Quotes order by Symbol for xml path('')), 1, 1, '') as Symbols;"
+	input += " select Symbol, Price from Quotes for json path, root('data'), include_null_values;"
+	input += " select j.id, j.name from openjson(@json, '$.items')"
+	input += " with (id int '$.id', name nvarchar(50) '$.name', tags nvarchar(max) '$.tags' as json) as j"
+
+	test(t, expected, input)
+}
+
+func TestFormatGenericFunctionCall(t *testing.T) {
+	expected := `SELECT
+    dbo.fn_Tax(Price) AS Tax
+    ,ISNULL(Note, '') AS Note
+    ,COALESCE(Bid, Ask, 0) AS Quote
+    ,DATEDIFF(DD, OpenDate, CloseDate) AS Days
+    ,STRING_AGG(Symbol, ',') WITHIN GROUP (ORDER BY Symbol, Price DESC) AS Symbols
+FROM Trades`
+
+	input := "select dbo.fn_Tax(Price) as Tax, ISNULL(Note, '') as Note, COALESCE(Bid, Ask, 0) as Quote,"
+	input += " DATEDIFF(dd, OpenDate, CloseDate) as Days,"
+	input += " STRING_AGG(Symbol, ',') within group (order by Symbol, Price desc) as Symbols from Trades"
+
+	test(t, expected, input)
+
+	expected = `SELECT
+    ISNULL(Note, '') AS Note
+    ,DATEADD(DAY, 1, TradeDate) AS NextDay
+    ,SUM(Price) AS Total
+    ,dbo.isnull(Note) AS Custom
+FROM Trades`
+
+	input = "select isnull(Note, '') as Note, dateadd(day, 1, TradeDate) as NextDay, sum(Price) as Total,"
+	input += " dbo.isnull(Note) as Custom from Trades"
+
+	test(t, expected, input)
+
+	expected = `SELECT
+    UPPER(Symbol) AS Symbol
+    ,NULLIF(Price, 0) AS Price
+    ,YEAR(TradeDate) AS TradeYear
+    ,LEFT(Symbol, 2) AS Prefix
+    ,CHAR(65) AS Letter
+FROM Trades
+LEFT JOIN Quotes ON 1 = 1`
+
+	input = "select upper(Symbol) as Symbol, nullif(Price, 0) as Price, year(TradeDate) as TradeYear,"
+	input += " left(Symbol, 2) as Prefix, char(65) as Letter from Trades left join Quotes on 1 = 1"
+
+	test(t, expected, input)
+}
+
+func TestFormatConversionFunctions(t *testing.T) {
+	expected := `SELECT
+    CONVERT(VARCHAR(10), TradeDate, 120) AS TradeDay
+    ,TRY_CONVERT(INT, Quantity) AS Quantity
+    ,TRY_CAST(Price AS DECIMAL(10, 2)) AS Price
+    ,PARSE(SettleDate AS DATETIME USING 'en-US') AS SettleDate
+    ,TRY_PARSE(Notional AS INT) AS Notional
+FROM Trades
+WHERE CONVERT(DATE, TradeDate) = '2024-01-02'`
+
+	input := "select convert(varchar(10), TradeDate, 120) as TradeDay, try_convert(int, Quantity) as Quantity,"
+	input += " try_cast(Price as decimal(10,2)) as Price, parse(SettleDate as datetime using 'en-US') as SettleDate,"
+	input += " try_parse(Notional as int) as Notional from Trades where convert(date, TradeDate) = '2024-01-02'"
+
+	test(t, expected, input)
+}
+
+func TestFormatInsertStatement(t *testing.T) {
+	expected := `INSERT INTO dbo.Trades (
+    Symbol
+    ,LastPrice
+    ,Note
+)
+VALUES
+    ('AAL', 12.5, NULL)
+    ,('AMZN', 180, 'open')
+
+INSERT TOP (5) dbo.Trades
+SELECT
+    Symbol
+    ,LastPrice
+FROM Staging
+
+INSERT INTO dbo.Audit
+DEFAULT VALUES`
+
+	input := "insert into dbo.Trades (Symbol, LastPrice, Note) values ('AAL', 12.5, null), ('AMZN', 180, 'open');"
+	input += " insert top (5) dbo.Trades select Symbol, LastPrice from Staging;"
+	input += " insert into dbo.Audit default values"
+
+	test(t, expected, input)
+}
+
+func TestFormatUpdateStatement(t *testing.T) {
+	expected := `UPDATE TOP (100) mkt
+SET
+    LastPrice = q.Price
+    ,Volume += q.Volume
+    ,@Updated = Updated = 1
+FROM MarketData mkt
+INNER JOIN Quotes q ON q.Symbol = mkt.Symbol
+WHERE mkt.LastPrice <> q.Price`
+
+	input := "update top (100) mkt set LastPrice = q.Price, Volume += q.Volume, @Updated = Updated = 1"
+	input += " from MarketData mkt inner join Quotes q on q.Symbol = mkt.Symbol where mkt.LastPrice <> q.Price"
+
+	test(t, expected, input)
+}
+
+func TestFormatDeleteStatement(t *testing.T) {
+	expected := `DELETE TOP (500) FROM MarketData
+WHERE LastPrice < 1
+
+DELETE mkt
+FROM MarketData mkt
+INNER JOIN Delisted d ON d.Symbol = mkt.Symbol`
+
+	input := "delete top (500) from MarketData where LastPrice < 1"
+	input += " delete mkt from MarketData mkt inner join Delisted d on d.Symbol = mkt.Symbol"
+
+	test(t, expected, input)
+}
+
+func TestFormatOutputClause(t *testing.T) {
+	expected := `INSERT INTO Quotes (
+    Symbol
+    ,Price
+)
+OUTPUT
+    inserted.Id
+    ,inserted.Symbol
+INTO @Audit (
+    Id
+    ,Symbol
+)
+VALUES ('AAL', 10)
+
+UPDATE Quotes
+SET Price = 11
+OUTPUT
+    deleted.Price AS OldPrice
+    ,inserted.Price
+WHERE Symbol = 'AAL'
+
+DELETE FROM Quotes
+OUTPUT deleted.*
+WHERE Symbol = 'AAL'`
+
+	input := "insert into Quotes (Symbol, Price) output inserted.Id, inserted.Symbol into @Audit (Id, Symbol) values ('AAL', 10);"
+	input += " update Quotes set Price = 11 output deleted.Price as OldPrice, inserted.Price where Symbol = 'AAL';"
+	input += " delete from Quotes output deleted.* where Symbol = 'AAL'"
+
+	test(t, expected, input)
+}
+
+func TestFormatMergeStatement(t *testing.T) {
+	expected := `MERGE INTO MarketData AS t
+USING Quotes s ON t.Symbol = s.Symbol
+WHEN MATCHED AND s.Price <> t.LastPrice THEN
+    UPDATE SET
+        LastPrice = s.Price
+        ,Volume += s.Volume
+WHEN NOT MATCHED BY TARGET THEN
+    INSERT (
+        Symbol
+        ,LastPrice
+    )
+    VALUES (s.Symbol, s.Price)
+WHEN NOT MATCHED BY SOURCE THEN
+    DELETE
+OUTPUT
+    $action
+    ,inserted.Symbol
+    ,deleted.LastPrice;
+
+MERGE Quotes AS q
+USING Staging s ON q.Symbol = s.Symbol
+WHEN MATCHED THEN
+    DELETE;
+
+SELECT 1`
+
+	input := "merge into MarketData as t using Quotes s on t.Symbol = s.Symbol"
+	input += " when matched and s.Price <> t.LastPrice then update set LastPrice = s.Price, Volume += s.Volume"
+	input += " when not matched by target then insert (Symbol, LastPrice) values (s.Symbol, s.Price)"
+	input += " when not matched by source then delete"
+	input += " output $action, inserted.Symbol, deleted.LastPrice;"
+	input += " merge Quotes as q using Staging s on q.Symbol = s.Symbol when matched then delete; select 1"
+
+	test(t, expected, input)
+}
+
+func TestFormatDeclareAndSetStatements(t *testing.T) {
+	expected := `DECLARE
+    @Count INT = 0
+    ,@Price DECIMAL(10, 2)
+
+DECLARE @Symbols TABLE (
+    Symbol     VARCHAR(10)    NOT NULL
+    ,LastPrice DECIMAL(10, 2) NULL
+)
+
+DECLARE symbol_cursor CURSOR FOR
+SELECT Symbol
+FROM MarketData
+
+SET @Count += 1
+
+SELECT
+    @Price = MAX(LastPrice)
+    ,@Count = COUNT(*)
+FROM MarketData`
+
+	input := "declare @Count int = 0, @Price decimal(10, 2);"
+	input += " declare @Symbols table (Symbol varchar(10) not null, LastPrice decimal(10, 2) null)"
+	input += " declare symbol_cursor cursor for select Symbol from MarketData;"
+	input += " set @Count += 1"
+	input += " select @Price = max(LastPrice), @Count = count(*) from MarketData"
+
+	test(t, expected, input)
+}
+
+func TestFormatControlOfFlowStatements(t *testing.T) {
+	expected := `WHILE @i < 10
+BEGIN
+    SET @i += 1
+    IF @i = 5
+        CONTINUE
+    ELSE IF @i > 8
+    BEGIN
+        SELECT @i
+        BREAK
+    END
+    ELSE
+        SET @i += 2
+END
+
+IF @i >= 10
+    RETURN 1
+ELSE
+    RETURN`
+
+	input := "while @i < 10 begin set @i += 1 if @i = 5 continue"
+	input += " else if @i > 8 begin select @i; break; end else set @i += 2 end"
+	input += " if @i >= 10 return 1; else return"
+
+	test(t, expected, input)
+}
+
+func TestFormatTryCatchStatement(t *testing.T) {
+	expected := `BEGIN TRY
+    PRINT 'loading ' + @Symbol
+    IF @Rows = 0
+        THROW 50000, 'no rows', 1
+END TRY
+BEGIN CATCH
+    RAISERROR('load failed for %s', 16, 1, @Symbol) WITH NOWAIT;
+    THROW
+END CATCH
+
+IF @@trancount > 0
+    ROLLBACK TRAN;
+
+THROW`
+
+	input := "begin try print 'loading ' + @Symbol if @Rows = 0 throw 50000, 'no rows', 1; end try"
+	input += " begin catch raiserror('load failed for %s', 16, 1, @Symbol) with nowait; throw; end catch"
+	input += " if @@trancount > 0 rollback tran; throw;"
+
+	test(t, expected, input)
+}
+
+func TestFormatSubquerySetOperations(t *testing.T) {
+	expected := `SELECT d.a
+FROM (
+        SELECT a
+        FROM u
+        UNION ALL
+        SELECT a
+        FROM v
+    ) d
+WHERE d.a IN (
+        SELECT a
+        FROM w
+        UNION
+        SELECT a
+        FROM x
+    )
+    AND EXISTS(
+            SELECT 1
+            FROM y
+            EXCEPT
+            SELECT 2
+            FROM z
+        )`
+
+	input := "select d.a from (select a from u union all select a from v) d"
+	input += " where d.a in (select a from w union select a from x) and exists (select 1 from y except select 2 from z)"
+
+	test(t, expected, input)
+}
+
+func TestFormatStatementTerminators(t *testing.T) {
+	expected := `SET @Rows = 1;
+
+WITH Latest AS (
+    SELECT Symbol
+    FROM Quotes
+)
+SELECT Symbol
+FROM Latest
+
+BEGIN
+    UPDATE Quotes
+    SET Price = 1;
+    WITH Stale AS (
+        SELECT Symbol
+        FROM Quotes
+    )
+    DELETE FROM Stale
+END`
+
+	input := "set @Rows = 1; with Latest as (select Symbol from Quotes) select Symbol from Latest"
+	input += " begin update Quotes set Price = 1; with Stale as (select Symbol from Quotes) delete from Stale end"
+
+	test(t, expected, input)
+
+	expected = `IF @Rows = 1
+    RETURN;
+
+(
+    SELECT 1
+)
+
+BEGIN
+    RETURN;
+    SELECT 2
+END`
+
+	input = "if @Rows = 1 return; (select 1) begin return; select 2 end"
+
+	test(t, expected, input)
+	// formatting the output again must not turn the query into the return value
+	test(t, expected, expected)
+
+	expected = `SELECT a
+FROM t;
+
+(
+    SELECT 1
+)
+UNION
+SELECT 2
+
+BEGIN
+    PRINT 'done';
+    (
+        SELECT 3
+    )
+END`
+
+	input = "select a from t; (select 1) union select 2 begin print 'done'; (select 3) end"
+
+	test(t, expected, input)
+	test(t, expected, expected)
+}
+
+func TestFormatTransactionStatements(t *testing.T) {
+	expected := `SET XACT_ABORT ON
+
+SET QUOTED_IDENTIFIER ON
+
+BEGIN TRAN LoadQuotes
+
+BEGIN TRY
+    SAVE TRANSACTION BeforeDelete
+    DELETE FROM Quotes
+    COMMIT TRAN LoadQuotes
+END TRY
+BEGIN CATCH
+    ROLLBACK TRAN BeforeDelete
+    ROLLBACK
+END CATCH`
+
+	input := "set xact_abort on; set quoted_identifier on; begin tran LoadQuotes"
+	input += " begin try save transaction BeforeDelete; delete from Quotes; commit tran LoadQuotes end try"
+	input += " begin catch rollback tran BeforeDelete; rollback; end catch"
+
+	test(t, expected, input)
+}
+
+func TestFormatExecuteStatement(t *testing.T) {
+	expected := `EXEC @ret = dbo.LoadQuotes
+    @Symbol = 'AAL'
+    ,@From = '2024-01-01'
+    ,@Rows = @Rows OUTPUT
+    ,DEFAULT
+
+EXEC (@sql)
+
+EXECUTE sp_executesql @stmt, N'@id INT', @id = 5`
+
+	input := "exec @ret = dbo.LoadQuotes @Symbol = 'AAL', @From = '2024-01-01', @Rows = @Rows output, default;"
+	input += " exec (@sql); execute sp_executesql @stmt, N'@id INT', @id = 5"
+
+	test(t, expected, input)
+}
+
+func TestFormatTableStatements(t *testing.T) {
+	expected := `CREATE TABLE dbo.Trades (
+    TradeId   INT            IDENTITY(1, 1) NOT NULL CONSTRAINT PK_Trades PRIMARY KEY CLUSTERED
+    ,Symbol   VARCHAR(10)    NOT NULL REFERENCES dbo.Symbols (Symbol) ON DELETE CASCADE
+    ,Price    DECIMAL(10, 2) NOT NULL CHECK (Price > 0)
+    ,Quantity INT            NULL DEFAULT 0
+    ,Note     NVARCHAR(MAX)
+    ,Total    AS (Price * Quantity) PERSISTED
+    ,CONSTRAINT UQ_Trades UNIQUE NONCLUSTERED (Symbol ASC, TradeId DESC)
+)
+
+ALTER TABLE dbo.Trades ADD
+    CreatedAt DATETIME NOT NULL DEFAULT GETDATE()
+    ,CONSTRAINT DF_Trades_Quantity DEFAULT 0 FOR Quantity
+
+ALTER TABLE dbo.Trades ALTER COLUMN Symbol VARCHAR(20) NOT NULL
+
+ALTER TABLE dbo.Trades DROP COLUMN IF EXISTS Note, CreatedAt
+
+DROP TABLE IF EXISTS dbo.Trades`
+
+	input := "create table dbo.Trades (TradeId int not null identity(1, 1) constraint PK_Trades primary key clustered,"
+	input += " Symbol varchar(10) not null references dbo.Symbols (Symbol) on delete cascade,"
+	input += " Price decimal(10, 2) not null check (Price > 0), Quantity int default 0 null, Note nvarchar(max),"
+	input += " Total as (Price * Quantity) persisted, constraint UQ_Trades unique nonclustered (Symbol asc, TradeId desc));"
+	input += " alter table dbo.Trades add CreatedAt datetime not null default getdate(),"
+	input += " constraint DF_Trades_Quantity default 0 for Quantity;"
+	input += " alter table dbo.Trades alter column Symbol varchar(20) not null;"
+	input += " alter table dbo.Trades drop column if exists Note, CreatedAt;"
+	input += " drop table if exists dbo.Trades"
+
+	test(t, expected, input)
+}
+
+func TestFormatRoutineDefinitions(t *testing.T) {
+	expected := `CREATE OR ALTER PROCEDURE dbo.LoadQuotes
+    @Symbol VARCHAR(10)
+    ,@From DATE = NULL
+    ,@Rows INT OUTPUT
+    ,@Quotes dbo.QuoteList READONLY
+WITH RECOMPILE, EXECUTE AS OWNER
+AS
+BEGIN
+    SET NOCOUNT ON
+    SELECT @Rows = COUNT(*)
+    FROM @Quotes
+END`
+
+	input := "create or alter procedure dbo.LoadQuotes @Symbol varchar(10), @From date = null, @Rows int output,"
+	input += " @Quotes dbo.QuoteList readonly with recompile, execute as owner as begin set nocount on;"
+	input += " select @Rows = count(*) from @Quotes end"
+
+	test(t, expected, input)
+
+	expected = `CREATE FUNCTION dbo.fn_LastPrice (
+    @Symbol VARCHAR(10)
+)
+RETURNS DECIMAL(10, 2)
+WITH SCHEMABINDING
+AS
+BEGIN
+    DECLARE @Price DECIMAL(10, 2)
+    SELECT @Price = MAX(LastPrice)
+    FROM dbo.Quotes
+    WHERE Symbol = @Symbol
+    RETURN @Price
+END`
+
+	input = "create function dbo.fn_LastPrice (@Symbol varchar(10)) returns decimal(10, 2) with schemabinding"
+	input += " as begin declare @Price decimal(10, 2); select @Price = max(LastPrice) from dbo.Quotes"
+	input += " where Symbol = @Symbol; return @Price end"
+
+	test(t, expected, input)
+
+	expected = `ALTER FUNCTION dbo.fn_Trades()
+RETURNS TABLE
+AS
+RETURN (
+    SELECT Symbol
+    FROM dbo.Trades
+)`
+
+	input = "alter function dbo.fn_Trades() returns table as return (select Symbol from dbo.Trades)"
+
+	test(t, expected, input)
+
+	expected = `CREATE FUNCTION dbo.fn_TopQuotes()
+RETURNS @Result TABLE (
+    Symbol VARCHAR(10)    NOT NULL
+    ,Price DECIMAL(10, 2)
+)
+AS
+BEGIN
+    INSERT INTO @Result
+    SELECT
+        Symbol
+        ,Price
+    FROM Quotes
+    RETURN
+END`
+
+	input = "create function dbo.fn_TopQuotes() returns @Result table (Symbol varchar(10) not null, Price decimal(10, 2))"
+	input += " as begin insert into @Result select Symbol, Price from Quotes; return; end"
+
+	test(t, expected, input)
+}
+
+func TestFormatViewIndexTriggerStatements(t *testing.T) {
+	expected := `CREATE OR ALTER VIEW dbo.ActiveQuotes (Symbol, LastPrice)
+WITH SCHEMABINDING
+AS
+SELECT
+    Symbol
+    ,LastPrice
+FROM dbo.Quotes
+WHERE IsActive = 1`
+
+	input := "create or alter view dbo.ActiveQuotes (Symbol, LastPrice) with schemabinding as"
+	input += " select Symbol, LastPrice from dbo.Quotes where IsActive = 1"
+
+	test(t, expected, input)
+
+	expected = `CREATE UNIQUE NONCLUSTERED INDEX IX_Quotes_Symbol ON dbo.Quotes (Symbol ASC, TradeDate DESC)
+INCLUDE (LastPrice, Volume)
+WHERE IsActive = 1
+WITH (FILLFACTOR = 80, ONLINE = ON)`
+
+	input = "create unique nonclustered index IX_Quotes_Symbol on dbo.Quotes (Symbol asc, TradeDate desc)"
+	input += " include (LastPrice, Volume) where IsActive = 1 with (FILLFACTOR = 80, ONLINE = on)"
+
+	test(t, expected, input)
+
+	expected = `CREATE TRIGGER dbo.trQuotes ON dbo.Quotes
+AFTER INSERT, UPDATE
+AS
+SET NOCOUNT ON
+UPDATE dbo.Quotes
+SET Processed = 0
+WHERE Symbol = 'AAL'`
+
+	input = "create trigger dbo.trQuotes on dbo.Quotes after insert, update as set nocount on;"
+	input += " update dbo.Quotes set Processed = 0 where Symbol = 'AAL'"
+
+	test(t, expected, input)
+}
+
+func TestFormatBatches(t *testing.T) {
+	expected := `CREATE PROCEDURE dbo.LoadQuotes
+AS
+SELECT Symbol
+FROM dbo.Quotes
+GO
+
+EXEC dbo.LoadQuotes
+GO 5
+
+SELECT Symbol
+FROM dbo.Trades`
+
+	input := "create procedure dbo.LoadQuotes as select Symbol from dbo.Quotes\ngo\n"
+	input += "exec dbo.LoadQuotes\n  go 5\nselect Symbol from dbo.Trades"
+
+	test(t, expected, input)
+}
+
 func test(t *testing.T, expected string, input string) {
 	logger, _ := zap.NewDevelopment()
 	defer logger.Sync()
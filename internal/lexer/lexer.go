@@ -228,6 +228,29 @@ func (l *Lexer) NextToken() Token {
 		localVariable := l.readIdentifier()
 		token.Type = TLocalVariable
 		token.Value = localVariable
+	case '#':
+		// temp tables like #tmp and ##global
+		start := l.current
+		if l.peekChar() == '#' {
+			l.readChar()
+		}
+		if peekChar := l.peekChar(); l.isAlphaNumeric(peekChar) || peekChar == '_' {
+			l.readChar()
+			l.readIdentifier()
+			token.Type = TIdentifier
+		} else {
+			token.Type = TSyntaxError
+		}
+		token.Value = l.input[start : l.current+1]
+	case '$':
+		// pseudo columns like $action
+		if l.isLetter(l.peekChar()) {
+			token.Type = TIdentifier
+			token.Value = l.readIdentifier()
+		} else {
+			token.Type = TSyntaxError
+			token.Value = "$"
+		}
 	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 		number := l.readNumber()
 		token.Type = TNumericLiteral
@@ -236,13 +259,27 @@ func (l *Lexer) NextToken() Token {
 		token.Type = TEndOfFile
 		token.Value = ""
 	default:
-		if l.isLetter(l.ch) || l.ch == '_' {
+		if (l.ch == 'N' || l.ch == 'n') && l.peekChar() == '\'' {
+			// unicode string literal N'...'
+			l.readChar()
+			stringLiteral := l.readQuotedString()
+			if l.ch == 0 {
+				token.Type = TSyntaxError
+			} else {
+				token.Type = TNationalStringLiteral
+			}
+			token.Value = stringLiteral
+		} else if l.isLetter(l.ch) || l.ch == '_' {
+			start := l.current
 			identifier := l.readIdentifier()
 			lowerIdentifier := strings.ToLower(identifier)
 			keyword, ok := Keywords[lowerIdentifier]
 			if ok {
 				token.Type = keyword
 				token.Value = identifier
+			} else if lowerIdentifier == "go" && l.isBatchSeparator(start) {
+				token.Type = TGo
+				token.Value = identifier
 			} else {
 				token.Type = TIdentifier
 				token.Value = identifier
@@ -310,6 +347,23 @@ func (l *Lexer) isAlphaNumeric(ch byte) bool {
 	return l.isLetter(ch) || l.isDigit(ch)
 }
 
+// GO only separates batches when it is alone on its line, it may be followed
+// by a repeat count or a comment
+func (l *Lexer) isBatchSeparator(start int) bool {
+	lineStart := strings.LastIndexByte(l.input[:start], '\n') + 1
+	if strings.TrimSpace(l.input[lineStart:start]) != "" {
+		return false
+	}
+
+	rest := l.input[min(l.current+1, len(l.input)):]
+	if lineEnd := strings.IndexByte(rest, '\n'); lineEnd >= 0 {
+		rest = rest[:lineEnd]
+	}
+	rest = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(rest), "0123456789"))
+
+	return rest == "" || strings.HasPrefix(rest, "--")
+}
+
 func (l *Lexer) readCommentLine() string {
 	// skip the -- characters
 	l.readChar()
@@ -352,22 +406,23 @@ func (l *Lexer) readQuotedIdentifier() string {
 
 func (l *Lexer) readQuotedString() string {
 	// skip the quote character
-	l.readChar()
-
-	// read the identifier until next quote
-	start := l.current
+	start := l.read
 
+	// read the string until the closing quote, two quotes in a row are an
+	// escaped quote
 	for {
-		peekChar := l.peekChar()
-		if peekChar == '\'' || peekChar == 0 {
+		l.readChar()
+		if l.ch == 0 {
 			break
 		}
-		l.readChar()
+		if l.ch == '\'' {
+			if l.peekChar() != '\'' {
+				break
+			}
+			l.readChar()
+		}
 	}
 
-	// go to the quote character
-	l.readChar()
-
 	return l.input[start:l.current]
 }
 
@@ -57,3 +57,42 @@ func TestBasic(t *testing.T) {
 	}
 
 }
+
+func TestTempTableNames(t *testing.T) {
+	expected := []Token{
+		{Type: TIdentifier, Value: "#tmp"},
+		{Type: TIdentifier, Value: "##global_1"},
+		{Type: TIdentifier, Value: "#_stage"},
+		{Type: TIdentifier, Value: "#1"},
+		{Type: TSyntaxError, Value: "#"},
+	}
+
+	lexer := NewLexer("#tmp ##global_1 #_stage #1 #")
+
+	for _, token := range expected {
+		current := lexer.NextToken()
+		if current.Type != token.Type || current.Value != token.Value {
+			t.Fatalf("expected %s %q, got %s %q", token.Type.String(), token.Value, current.Type.String(), current.Value)
+		}
+	}
+}
+
+func TestBatchSeparator(t *testing.T) {
+	expected := []TokenType{TSelect, TIdentifier, TGo, TNumericLiteral, TSelect, TIdentifier, TIdentifier, TGo, TCommentLine}
+
+	lexer := NewLexer("select go\n  GO 5\nselect a go\ngo -- end")
+
+	lexed := []TokenType{}
+	for current := lexer.NextToken(); current.Type != TEndOfFile; current = lexer.NextToken() {
+		lexed = append(lexed, current.Type)
+	}
+
+	if len(lexed) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(lexed))
+	}
+	for i, tokenType := range lexed {
+		if tokenType != expected[i] {
+			t.Fatalf("expected %s, got %s", expected[i].String(), tokenType.String())
+		}
+	}
+}
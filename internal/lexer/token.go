@@ -12,7 +12,7 @@ type Position struct {
 	Col  uint
 }
 
-type TokenType uint8
+type TokenType uint16
 
 const (
 	TEndOfFile TokenType = iota
@@ -25,6 +25,7 @@ const (
 	TIdentifier
 	TNumericLiteral
 	TStringLiteral
+	TNationalStringLiteral
 	TQuotedIdentifier
 
 	TComma
@@ -63,6 +64,7 @@ const (
 	// Keywords
 	TAbs
 	TAcos
+	TAdd
 	TAll
 	TAlter
 	TAnd
@@ -77,23 +79,30 @@ const (
 	TBetween
 	TBigint
 	TBit
+	TBreak
 	TBy
 	TCascade
 	TCase
 	TCast
+	TCatch
 	TCeil
 	TCeiling
 	TChar
+	TCheck
 	TColumn
 	TColumns
 	TCommit
 	TCommited
 	TConstraint
+	TContinue
+	TConvert
 	TCos
 	TCot
 	TCount
 	TCreate
+	TCross
 	TCurrent
+	TCursor
 	TDate
 	TDatetime
 	TDay
@@ -115,6 +124,7 @@ const (
 	TEngine
 	TExec
 	TExecute
+	TExcept
 	TExists
 	TExp
 	TFalse
@@ -124,12 +134,16 @@ const (
 	TFloat
 	TFloor
 	TFollowing
+	TFor
 	TForeign
 	TFrom
 	TFull
 	TFunction
 	TGetdate
+	TGo
 	TGroup
+	TGrouping
+	TGroupingId
 	THaving
 	THour
 	THours
@@ -156,7 +170,9 @@ const (
 	TLimit
 	TLog
 	TLog10
+	TMatched
 	TMax
+	TMerge
 	TMicrosecond
 	TMicroseconds
 	TMillisecond
@@ -168,30 +184,40 @@ const (
 	TNanoseconds
 	TNchar
 	TNext
+	TNonclustered
 	TNot
 	TNull
 	TNullif
 	TNumeric
 	TNvarchar
+	TOf
 	TOffset
 	TOn
 	TOnly
+	TOption
 	TOr
 	TOrder
 	TOuter
+	TOutput
 	TOver
 	TPartition
 	TPassword
 	TPercent
 	TPi
+	TPivot
 	TPower
 	TPreceding
+	TPrimary
+	TPrint
+	TProc
 	TProcedure
 	TRadians
+	TRaiserror
 	TRands
 	TRange
 	TRank
 	TReal
+	TReferences
 	TReturn
 	TReturns
 	TRevoke
@@ -202,6 +228,7 @@ const (
 	TRow
 	// TRowid
 	TRows
+	TSave
 	TRowNumber
 	TSecond
 	TSelect
@@ -223,23 +250,29 @@ const (
 	TTan
 	TTemp
 	TThen
+	TThrow
 	TTies
 	TTime
 	TTinyint
 	TTop
+	TTran
 	TTransaction
 	TTrigger
 	TTrue
 	TTruncate
+	TTry
+	TTryConvert
 	TUnbounded
 	TUncommitted
 	TUnion
 	TUnique
 	TUnlock
+	TUnpivot
 	TUpdate
 	TUpper
 	TUse
 	TUser
+	TUsing
 	TUuid
 	TValue
 	TValues
@@ -247,13 +280,17 @@ const (
 	TVarchar
 	TVar
 	TVarp
+	TView
 	TWeek
 	TWhen
 	TWhere
+	TWhile
 	TWindow
 	TWith
+	TWork
 	TYear
 	TChecksum
+	TClustered
 	TNewId
 )
 
@@ -261,6 +298,7 @@ var Keywords = map[string]TokenType{
 	// Reserved Keywords
 	"abs":           TAbs,
 	"acos":          TAcos,
+	"add":           TAdd,
 	"all":           TAll,
 	"alter":         TAlter,
 	"and":           TAnd,
@@ -275,23 +313,30 @@ var Keywords = map[string]TokenType{
 	"between":       TBetween,
 	"bigint":        TBigint,
 	"bit":           TBit,
+	"break":         TBreak,
 	"by":            TBy,
 	"cascade":       TCascade,
 	"case":          TCase,
 	"cast":          TCast,
+	"catch":         TCatch,
 	"ceil":          TCeil,
 	"ceiling":       TCeiling,
 	"char":          TChar,
+	"check":         TCheck,
 	"column":        TColumn,
 	"columns":       TColumns,
 	"commit":        TCommit,
 	"commited":      TCommited,
 	"constraint":    TConstraint,
+	"continue":      TContinue,
+	"convert":       TConvert,
 	"cos":           TCos,
 	"cot":           TCot,
 	"count":         TCount,
 	"create":        TCreate,
+	"cross":         TCross,
 	"current":       TCurrent,
+	"cursor":        TCursor,
 	"date":          TDate,
 	"datetime":      TDatetime,
 	"day":           TDay,
@@ -313,6 +358,7 @@ var Keywords = map[string]TokenType{
 	"engine":        TEngine,
 	"exec":          TExec,
 	"execute":       TExecute,
+	"except":        TExcept,
 	"exists":        TExists,
 	"exp":           TExp,
 	"false":         TFalse,
@@ -322,12 +368,15 @@ var Keywords = map[string]TokenType{
 	"float":         TFloat,
 	"floor":         TFloor,
 	"following":     TFollowing,
+	"for":           TFor,
 	"foreign":       TForeign,
 	"from":          TFrom,
 	"full":          TFull,
 	"function":      TFunction,
 	"getdate":       TGetdate,
 	"group":         TGroup,
+	"grouping":      TGrouping,
+	"grouping_id":   TGroupingId,
 	"having":        THaving,
 	"hour":          THour,
 	"hours":         THours,
@@ -354,7 +403,9 @@ var Keywords = map[string]TokenType{
 	"limit":         TLimit,
 	"log":           TLog,
 	"log10":         TLog10,
+	"matched":       TMatched,
 	"max":           TMax,
+	"merge":         TMerge,
 	"microsecond":   TMicrosecond,
 	"microseconds":  TMicroseconds,
 	"millisecond":   TMillisecond,
@@ -366,30 +417,40 @@ var Keywords = map[string]TokenType{
 	"nanoseconds":   TNanoseconds,
 	"nchar":         TNchar,
 	"next":          TNext,
+	"nonclustered":  TNonclustered,
 	"not":           TNot,
 	"null":          TNull,
 	"nullif":        TNullif,
 	"numeric":       TNumeric,
 	"nvarchar":      TNvarchar,
+	"of":            TOf,
 	"offset":        TOffset,
 	"on":            TOn,
 	"only":          TOnly,
+	"option":        TOption,
 	"or":            TOr,
 	"order":         TOrder,
 	"outer":         TOuter,
+	"output":        TOutput,
 	"over":          TOver,
 	"partition":     TPartition,
 	"password":      TPassword,
 	"percent":       TPercent,
 	"pi":            TPi,
+	"pivot":         TPivot,
 	"power":         TPower,
 	"preceding":     TPreceding,
+	"primary":       TPrimary,
+	"print":         TPrint,
+	"proc":          TProc,
 	"procedure":     TProcedure,
 	"radians":       TRadians,
+	"raiserror":     TRaiserror,
 	"rands":         TRands,
 	"range":         TRange,
 	"rank":          TRank,
 	"real":          TReal,
+	"references":    TReferences,
 	"return":        TReturn,
 	"returns":       TReturns,
 	"revoke":        TRevoke,
@@ -400,6 +461,7 @@ var Keywords = map[string]TokenType{
 	"row":           TRow,
 	// "rowid":         TRowid,
 	"rows":        TRows,
+	"save":        TSave,
 	"row_number":  TRowNumber,
 	"second":      TSecond,
 	"select":      TSelect,
@@ -421,23 +483,29 @@ var Keywords = map[string]TokenType{
 	"tan":         TTan,
 	"temp":        TTemp,
 	"then":        TThen,
+	"throw":       TThrow,
 	"ties":        TTies,
 	"time":        TTime,
 	"tinyint":     TTinyint,
 	"top":         TTop,
+	"tran":        TTran,
 	"transaction": TTransaction,
 	"trigger":     TTrigger,
 	"true":        TTrue,
 	"truncate":    TTruncate,
+	"try":         TTry,
+	"try_convert": TTryConvert,
 	"unbounded":   TUnbounded,
 	"uncommitted": TUncommitted,
 	"union":       TUnion,
 	"unique":      TUnique,
 	"unlock":      TUnlock,
+	"unpivot":     TUnpivot,
 	"update":      TUpdate,
 	"upper":       TUpper,
 	"use":         TUse,
 	"user":        TUser,
+	"using":       TUsing,
 	"uuid":        TUuid,
 	"value":       TValue,
 	"values":      TValues,
@@ -445,13 +513,17 @@ var Keywords = map[string]TokenType{
 	"varchar":     TVarchar,
 	"var":         TVar,
 	"varp":        TVarp,
+	"view":        TView,
 	"week":        TWeek,
 	"when":        TWhen,
 	"where":       TWhere,
+	"while":       TWhile,
 	"window":      TWindow,
 	"with":        TWith,
+	"work":        TWork,
 	"year":        TYear,
 	"checksum":    TChecksum,
+	"clustered":   TClustered,
 	"newid":       TNewId,
 }
 
@@ -465,6 +537,8 @@ func (t TokenType) IsBuiltinFunction() bool {
 		TAsin,
 		TAtan,
         TCast,
+		TConvert,
+		TTryConvert,
 		TCeiling,
 		TCos,
 		TCot,
@@ -498,7 +572,9 @@ func (t TokenType) IsBuiltinFunction() bool {
 		TVarp,
 		TGetdate,
         TChecksum,
-        TNewId:
+        TNewId,
+		TGrouping,
+		TGroupingId:
 		return true
 	default:
 		return false
@@ -521,6 +597,8 @@ func (t TokenType) String() string {
 		return "NumericLiteral"
 	case TStringLiteral:
 		return "StringLiteral"
+	case TNationalStringLiteral:
+		return "NationalStringLiteral"
 	case TQuotedIdentifier:
 		return "QuotedIdentifier"
 	case TComma:
@@ -591,6 +669,8 @@ func (t TokenType) String() string {
 		return "Abs"
 	case TAcos:
 		return "Acos"
+	case TAdd:
+		return "Add"
 	case TAll:
 		return "All"
 	case TAlter:
@@ -619,6 +699,8 @@ func (t TokenType) String() string {
 		return "Bigint"
 	case TBit:
 		return "Bit"
+	case TBreak:
+		return "Break"
 	case TBy:
 		return "By"
 	case TCascade:
@@ -627,12 +709,16 @@ func (t TokenType) String() string {
 		return "Case"
 	case TCast:
 		return "Cast"
+	case TCatch:
+		return "Catch"
 	case TCeil:
 		return "Ceil"
 	case TCeiling:
 		return "Ceiling"
 	case TChar:
 		return "Char"
+	case TCheck:
+		return "Check"
 	case TColumn:
 		return "Column"
 	case TColumns:
@@ -643,6 +729,10 @@ func (t TokenType) String() string {
 		return "Commited"
 	case TConstraint:
 		return "Constraint"
+	case TContinue:
+		return "Continue"
+	case TConvert:
+		return "Convert"
 	case TCos:
 		return "Cos"
 	case TCot:
@@ -651,8 +741,12 @@ func (t TokenType) String() string {
 		return "Count"
 	case TCreate:
 		return "Create"
+	case TCross:
+		return "Cross"
 	case TCurrent:
 		return "Current"
+	case TCursor:
+		return "Cursor"
 	case TDate:
 		return "Date"
 	case TDatetime:
@@ -695,6 +789,8 @@ func (t TokenType) String() string {
 		return "Exec"
 	case TExecute:
 		return "Execute"
+	case TExcept:
+		return "Except"
 	case TExists:
 		return "Exists"
 	case TExp:
@@ -713,6 +809,8 @@ func (t TokenType) String() string {
 		return "Floor"
 	case TFollowing:
 		return "Following"
+	case TFor:
+		return "For"
 	case TForeign:
 		return "Foreign"
 	case TFrom:
@@ -723,8 +821,14 @@ func (t TokenType) String() string {
 		return "Function"
 	case TGetdate:
 		return "Getdate"
+	case TGo:
+		return "Go"
 	case TGroup:
 		return "Group"
+	case TGrouping:
+		return "Grouping"
+	case TGroupingId:
+		return "GroupingId"
 	case THaving:
 		return "Having"
 	case THour:
@@ -777,8 +881,12 @@ func (t TokenType) String() string {
 		return "Log"
 	case TLog10:
 		return "Log10"
+	case TMatched:
+		return "Matched"
 	case TMax:
 		return "Max"
+	case TMerge:
+		return "Merge"
 	case TMicrosecond:
 		return "Microsecond"
 	case TMicroseconds:
@@ -801,6 +909,8 @@ func (t TokenType) String() string {
 		return "Nchar"
 	case TNext:
 		return "Next"
+	case TNonclustered:
+		return "Nonclustered"
 	case TNot:
 		return "Not"
 	case TNull:
@@ -811,18 +921,24 @@ func (t TokenType) String() string {
 		return "Numeric"
 	case TNvarchar:
 		return "Nvarchar"
+	case TOf:
+		return "Of"
 	case TOffset:
 		return "Offset"
 	case TOn:
 		return "On"
 	case TOnly:
 		return "Only"
+	case TOption:
+		return "Option"
 	case TOr:
 		return "Or"
 	case TOrder:
 		return "Order"
 	case TOuter:
 		return "Outer"
+	case TOutput:
+		return "Output"
 	case TOver:
 		return "Over"
 	case TPartition:
@@ -833,14 +949,24 @@ func (t TokenType) String() string {
 		return "Percent"
 	case TPi:
 		return "Pi"
+	case TPivot:
+		return "Pivot"
 	case TPower:
 		return "Power"
 	case TPreceding:
 		return "Preceding"
+	case TPrimary:
+		return "Primary"
+	case TPrint:
+		return "Print"
+	case TProc:
+		return "Proc"
 	case TProcedure:
 		return "Procedure"
 	case TRadians:
 		return "Radians"
+	case TRaiserror:
+		return "Raiserror"
 	case TRands:
 		return "Rands"
 	case TRange:
@@ -849,6 +975,8 @@ func (t TokenType) String() string {
 		return "Rank"
 	case TReal:
 		return "Real"
+	case TReferences:
+		return "References"
 	case TReturn:
 		return "Return"
 	case TReturns:
@@ -869,6 +997,8 @@ func (t TokenType) String() string {
 	// 	return "Rowid"
 	case TRows:
 		return "Rows"
+	case TSave:
+		return "Save"
 	case TRowNumber:
 		return "RowNumber"
 	case TSecond:
@@ -911,6 +1041,8 @@ func (t TokenType) String() string {
 		return "Temp"
 	case TThen:
 		return "Then"
+	case TThrow:
+		return "Throw"
 	case TTies:
 		return "Ties"
 	case TTime:
@@ -919,6 +1051,8 @@ func (t TokenType) String() string {
 		return "Tinyint"
 	case TTop:
 		return "Top"
+	case TTran:
+		return "Tran"
 	case TTransaction:
 		return "Transaction"
 	case TTrigger:
@@ -927,6 +1061,10 @@ func (t TokenType) String() string {
 		return "True"
 	case TTruncate:
 		return "Truncate"
+	case TTry:
+		return "Try"
+	case TTryConvert:
+		return "TryConvert"
 	case TUnbounded:
 		return "Unbounded"
 	case TUncommitted:
@@ -937,6 +1075,8 @@ func (t TokenType) String() string {
 		return "Unique"
 	case TUnlock:
 		return "Unlock"
+	case TUnpivot:
+		return "Unpivot"
 	case TUpdate:
 		return "Update"
 	case TUpper:
@@ -945,6 +1085,8 @@ func (t TokenType) String() string {
 		return "Use"
 	case TUser:
 		return "User"
+	case TUsing:
+		return "Using"
 	case TUuid:
 		return "Uuid"
 	case TValue:
@@ -959,20 +1101,28 @@ func (t TokenType) String() string {
 		return "Var"
 	case TVarp:
 		return "Varp"
+	case TView:
+		return "View"
 	case TWeek:
 		return "Week"
 	case TWhen:
 		return "When"
 	case TWhere:
 		return "Where"
+	case TWhile:
+		return "While"
 	case TWindow:
 		return "Window"
 	case TWith:
 		return "With"
+	case TWork:
+		return "Work"
 	case TYear:
 		return "Year"
 	case TChecksum:
 		return "Checksum"
+	case TClustered:
+		return "Clustered"
 	case TNewId:
 		return "NewId"
 	}
@@ -0,0 +1,1134 @@
+package parser
+
+import (
+	"SequelGo/internal/ast"
+	"SequelGo/internal/lexer"
+)
+
+func (p *Parser) parseCreateStatement() (ast.Statement, error) {
+	createKw, err := p.consumeKeyword(lexer.TCreate)
+	if err != nil {
+		return nil, err
+	}
+	createKeywords := []ast.Keyword{*createKw}
+	if orKw := p.maybeKeyword(lexer.TOr); orKw != nil {
+		alterKw, err := p.consumeKeyword(lexer.TAlter)
+		if err != nil {
+			return nil, err
+		}
+		createKeywords = append(createKeywords, *orKw, *alterKw)
+	}
+
+	switch p.peekToken.Type {
+	case lexer.TTable:
+		if len(createKeywords) > 1 {
+			return nil, p.peekErrorMany([]lexer.TokenType{lexer.TProc, lexer.TProcedure, lexer.TFunction})
+		}
+		return p.parseCreateTableStatement(*createKw)
+	case lexer.TProc, lexer.TProcedure:
+		return p.parseCreateProcedureStatement(createKeywords)
+	case lexer.TFunction:
+		return p.parseCreateFunctionStatement(createKeywords)
+	case lexer.TView:
+		return p.parseCreateViewStatement(createKeywords)
+	case lexer.TTrigger:
+		return p.parseCreateTriggerStatement(createKeywords)
+	case lexer.TUnique, lexer.TClustered, lexer.TNonclustered, lexer.TIndex:
+		if len(createKeywords) > 1 {
+			return nil, p.peekErrorMany([]lexer.TokenType{
+				lexer.TProc,
+				lexer.TProcedure,
+				lexer.TFunction,
+				lexer.TView,
+				lexer.TTrigger,
+			})
+		}
+		return p.parseCreateIndexStatement(*createKw)
+	default:
+		return nil, p.peekErrorMany([]lexer.TokenType{
+			lexer.TTable,
+			lexer.TProc,
+			lexer.TProcedure,
+			lexer.TFunction,
+			lexer.TView,
+			lexer.TIndex,
+			lexer.TTrigger,
+		})
+	}
+}
+
+func (p *Parser) parseAlterStatement() (ast.Statement, error) {
+	alterKw, err := p.consumeKeyword(lexer.TAlter)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.peekToken.Type {
+	case lexer.TTable:
+		return p.parseAlterTableStatement(*alterKw)
+	case lexer.TProc, lexer.TProcedure:
+		return p.parseCreateProcedureStatement([]ast.Keyword{*alterKw})
+	case lexer.TFunction:
+		return p.parseCreateFunctionStatement([]ast.Keyword{*alterKw})
+	case lexer.TView:
+		return p.parseCreateViewStatement([]ast.Keyword{*alterKw})
+	case lexer.TTrigger:
+		return p.parseCreateTriggerStatement([]ast.Keyword{*alterKw})
+	default:
+		return nil, p.peekErrorMany([]lexer.TokenType{
+			lexer.TTable,
+			lexer.TProc,
+			lexer.TProcedure,
+			lexer.TFunction,
+			lexer.TView,
+			lexer.TTrigger,
+		})
+	}
+}
+
+func (p *Parser) parseDropStatement() (ast.Statement, error) {
+	dropKw, err := p.consumeKeyword(lexer.TDrop)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.peekToken.Type {
+	case lexer.TTable:
+		return p.parseDropTableStatement(*dropKw)
+	default:
+		return nil, p.peekErrorMany([]lexer.TokenType{lexer.TTable})
+	}
+}
+
+func (p *Parser) parseCreateTableStatement(createKw ast.Keyword) (*ast.CreateTableStatement, error) {
+	tableKw, err := p.consumeKeyword(lexer.TTable)
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	definitions, rightParen, err := p.parseTableElements()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.CreateTableStatement{
+		CreateKeyword: createKw,
+		TableKeyword:  *tableKw,
+		Name:          name,
+		Definitions:   definitions,
+		Span:          ast.NewSpanFromLexerPosition(createKw.StartPosition, rightParen.End),
+	}, nil
+}
+
+func (p *Parser) parseAlterTableStatement(alterKw ast.Keyword) (*ast.AlterTableStatement, error) {
+	tableKw, err := p.consumeKeyword(lexer.TTable)
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+
+	var action ast.AlterTableAction
+	switch p.peekToken.Type {
+	case lexer.TAdd:
+		action, err = p.parseAlterTableAddAction()
+	case lexer.TDrop:
+		action, err = p.parseAlterTableDropAction()
+	case lexer.TAlter:
+		action, err = p.parseAlterTableAlterColumnAction()
+	default:
+		return nil, p.peekErrorMany([]lexer.TokenType{lexer.TAdd, lexer.TDrop, lexer.TAlter})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.AlterTableStatement{
+		AlterKeyword: alterKw,
+		TableKeyword: *tableKw,
+		Name:         name,
+		Action:       action,
+		Span:         ast.NewSpanFromLexerPosition(alterKw.StartPosition, action.GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseAlterTableAddAction() (*ast.AlterTableAddAction, error) {
+	addKw, err := p.consumeKeyword(lexer.TAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &ast.AlterTableAddAction{AddKeyword: *addKw}
+	for {
+		definition, err := p.parseTableElement()
+		if err != nil {
+			return nil, err
+		}
+		action.Definitions = append(action.Definitions, definition)
+		action.Span = ast.NewSpanFromLexerPosition(addKw.StartPosition, definition.GetSpan().EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return action, nil
+}
+
+func (p *Parser) parseAlterTableDropAction() (*ast.AlterTableDropAction, error) {
+	dropKw, err := p.consumeKeyword(lexer.TDrop)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &ast.AlterTableDropAction{DropKeyword: *dropKw}
+	if targetKw := p.maybeKeyword(lexer.TColumn); targetKw != nil {
+		action.TargetKeyword = targetKw
+	} else if targetKw := p.maybeKeyword(lexer.TConstraint); targetKw != nil {
+		action.TargetKeyword = targetKw
+	}
+	action.IfExistsKeywords, err = p.maybeIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := p.parseObjectNames()
+	if err != nil {
+		return nil, err
+	}
+	action.Names = names
+	action.Span = ast.NewSpanFromLexerPosition(dropKw.StartPosition, names[len(names)-1].GetSpan().EndPosition)
+
+	return action, nil
+}
+
+func (p *Parser) parseAlterTableAlterColumnAction() (*ast.AlterTableAlterColumnAction, error) {
+	alterKw, err := p.consumeKeyword(lexer.TAlter)
+	if err != nil {
+		return nil, err
+	}
+	columnKw, err := p.consumeKeyword(lexer.TColumn)
+	if err != nil {
+		return nil, err
+	}
+	column, err := p.parseColumnDefinition()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.AlterTableAlterColumnAction{
+		AlterKeyword:  *alterKw,
+		ColumnKeyword: *columnKw,
+		Column:        column,
+		Span:          ast.NewSpanFromLexerPosition(alterKw.StartPosition, column.EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseDropTableStatement(dropKw ast.Keyword) (*ast.DropTableStatement, error) {
+	tableKw, err := p.consumeKeyword(lexer.TTable)
+	if err != nil {
+		return nil, err
+	}
+	ifExists, err := p.maybeIfExists()
+	if err != nil {
+		return nil, err
+	}
+	names, err := p.parseObjectNames()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.DropTableStatement{
+		DropKeyword:      dropKw,
+		TableKeyword:     *tableKw,
+		IfExistsKeywords: ifExists,
+		Names:            names,
+		Span:             ast.NewSpanFromLexerPosition(dropKw.StartPosition, names[len(names)-1].GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseCreateProcedureStatement(createKeywords []ast.Keyword) (*ast.CreateProcedureStatement, error) {
+	procedureKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TProc, lexer.TProcedure})
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.CreateProcedureStatement{
+		CreateKeywords:   createKeywords,
+		ProcedureKeyword: *procedureKw,
+		Name:             name,
+	}
+
+	// the parameters of a procedure may or may not be parenthesized
+	leftParen := p.maybeToken(lexer.TLeftParen)
+	if leftParen != nil || p.peekTokenIs(lexer.TLocalVariable) {
+		stmt.Parameters, err = p.parseRoutineParameters()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if leftParen != nil {
+		if _, err := p.consumeToken(lexer.TRightParen); err != nil {
+			return nil, err
+		}
+	}
+
+	stmt.WithKeyword, stmt.Options, err = p.maybeRoutineOptions()
+	if err != nil {
+		return nil, err
+	}
+	asKw, err := p.consumeKeyword(lexer.TAs)
+	if err != nil {
+		return nil, err
+	}
+	stmt.AsKeyword = *asKw
+	stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, asKw.EndPosition)
+
+	// the body of a procedure runs to the end of the batch
+	for !p.peekTokenIsAny(batch_end) {
+		if token := p.maybeToken(lexer.TSemiColon); token != nil {
+			continue
+		}
+
+		statement, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Statements = append(stmt.Statements, statement)
+		stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, statement.GetSpan().EndPosition)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCreateFunctionStatement(createKeywords []ast.Keyword) (*ast.CreateFunctionStatement, error) {
+	functionKw, err := p.consumeKeyword(lexer.TFunction)
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.CreateFunctionStatement{
+		CreateKeywords:  createKeywords,
+		FunctionKeyword: *functionKw,
+		Name:            name,
+	}
+
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	if p.peekTokenIs(lexer.TLocalVariable) {
+		stmt.Parameters, err = p.parseRoutineParameters()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.consumeToken(lexer.TRightParen); err != nil {
+		return nil, err
+	}
+
+	returnsKw, err := p.consumeKeyword(lexer.TReturns)
+	if err != nil {
+		return nil, err
+	}
+	stmt.ReturnsKeyword = *returnsKw
+	switch p.peekToken.Type {
+	case lexer.TTable:
+		stmt.Type = ast.FTInlineTable
+		stmt.ReturnTableKeyword, _ = p.consumeKeyword(lexer.TTable)
+	case lexer.TLocalVariable:
+		stmt.Type = ast.FTMultiStatementTable
+		startPosition := p.peekToken.Start
+		variable := ast.ExprLocalVariable{Value: p.peekToken.Value, Span: ast.NewSpanFromToken(p.peekToken)}
+		p.nextToken()
+		tableKw, err := p.consumeKeyword(lexer.TTable)
+		if err != nil {
+			return nil, err
+		}
+		definitions, rightParen, err := p.parseTableElements()
+		if err != nil {
+			return nil, err
+		}
+		stmt.ReturnVariable = &ast.TableVariableDeclaration{
+			Name:         variable,
+			TableKeyword: *tableKw,
+			Definitions:  definitions,
+			Span:         ast.NewSpanFromLexerPosition(startPosition, rightParen.End),
+		}
+	default:
+		stmt.Type = ast.FTScalar
+		stmt.ReturnType, err = p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stmt.WithKeyword, stmt.Options, err = p.maybeRoutineOptions()
+	if err != nil {
+		return nil, err
+	}
+	stmt.AsKeyword = p.maybeKeyword(lexer.TAs)
+
+	if stmt.Type == ast.FTInlineTable {
+		returnKw, err := p.consumeKeyword(lexer.TReturn)
+		if err != nil {
+			return nil, err
+		}
+		query, err := p.parseQueryExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.ReturnKeyword = returnKw
+		stmt.ReturnQuery = query
+		stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, query.GetSpan().EndPosition)
+		return stmt, nil
+	}
+
+	beginKw, err := p.consumeKeyword(lexer.TBegin)
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlockStatement(*beginKw)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Body = body
+	stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, body.EndPosition)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCreateViewStatement(createKeywords []ast.Keyword) (*ast.CreateViewStatement, error) {
+	viewKw, err := p.consumeKeyword(lexer.TView)
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.CreateViewStatement{
+		CreateKeywords: createKeywords,
+		ViewKeyword:    *viewKw,
+		Name:           name,
+	}
+
+	if p.peekTokenIs(lexer.TLeftParen) {
+		stmt.Columns, err = p.parseColumnList()
+		if err != nil {
+			return nil, err
+		}
+	}
+	stmt.WithKeyword, stmt.Options, err = p.maybeRoutineOptions()
+	if err != nil {
+		return nil, err
+	}
+	asKw, err := p.consumeKeyword(lexer.TAs)
+	if err != nil {
+		return nil, err
+	}
+	stmt.AsKeyword = *asKw
+
+	if err := p.expectPeekMany([]lexer.TokenType{lexer.TSelect, lexer.TLeftParen, lexer.TWith}); err != nil {
+		return nil, err
+	}
+	statement, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	query, ok := statement.(*ast.SelectStatement)
+	if !ok {
+		return nil, p.peekErrorString("the end of the view")
+	}
+	stmt.Query = query
+	stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, query.EndPosition)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCreateIndexStatement(createKw ast.Keyword) (*ast.CreateIndexStatement, error) {
+	stmt := &ast.CreateIndexStatement{CreateKeyword: createKw}
+	stmt.UniqueKeyword = p.maybeKeyword(lexer.TUnique)
+	if clusteredKw := p.maybeKeyword(lexer.TClustered); clusteredKw != nil {
+		stmt.ClusteredKeyword = clusteredKw
+	} else if clusteredKw := p.maybeKeyword(lexer.TNonclustered); clusteredKw != nil {
+		stmt.ClusteredKeyword = clusteredKw
+	}
+	indexKw, err := p.consumeKeyword(lexer.TIndex)
+	if err != nil {
+		return nil, err
+	}
+	stmt.IndexKeyword = *indexKw
+	stmt.Name, err = p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	onKw, err := p.consumeKeyword(lexer.TOn)
+	if err != nil {
+		return nil, err
+	}
+	stmt.OnKeyword = *onKw
+	stmt.Table, err = p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	stmt.Columns, err = p.parseOrderByArgs()
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Span = ast.NewSpanFromLexerPosition(createKw.StartPosition, rightParen.End)
+
+	if includeKw := p.maybeIdentifierKeyword("include"); includeKw != nil {
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return nil, err
+		}
+		stmt.IncludeKeyword = includeKw
+		stmt.IncludeColumns = columns
+		stmt.Span = ast.NewSpanFromLexerPosition(createKw.StartPosition, columns.EndPosition)
+	}
+	if p.peekTokenIs(lexer.TWhere) {
+		stmt.WhereClause, err = p.parseWhereExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Span = ast.NewSpanFromLexerPosition(createKw.StartPosition, stmt.WhereClause.EndPosition)
+	}
+	if withKw := p.maybeKeyword(lexer.TWith); withKw != nil {
+		if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+			return nil, err
+		}
+		for {
+			option, err := p.parseIndexOption()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Options = append(stmt.Options, *option)
+
+			if token := p.maybeToken(lexer.TComma); token == nil {
+				break
+			}
+		}
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		stmt.WithKeyword = withKw
+		stmt.Span = ast.NewSpanFromLexerPosition(createKw.StartPosition, rightParen.End)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseIndexOption() (*ast.IndexOption, error) {
+	nameToken, err := p.consumeToken(lexer.TIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	option := &ast.IndexOption{
+		Name: &ast.ExprIdentifier{Value: nameToken.Value, Span: ast.NewSpanFromToken(*nameToken)},
+	}
+	if _, err := p.consumeToken(lexer.TEqual); err != nil {
+		return nil, err
+	}
+
+	if valueKw := p.maybeKeyword(lexer.TOn); valueKw != nil {
+		option.ValueKeyword = valueKw
+		option.Span = ast.NewSpanFromLexerPosition(nameToken.Start, valueKw.EndPosition)
+	} else if valueKw := p.maybeIdentifierKeyword("off"); valueKw != nil {
+		option.ValueKeyword = valueKw
+		option.Span = ast.NewSpanFromLexerPosition(nameToken.Start, valueKw.EndPosition)
+	} else {
+		option.Value, err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		option.Span = ast.NewSpanFromLexerPosition(nameToken.Start, option.Value.GetSpan().EndPosition)
+	}
+
+	return option, nil
+}
+
+var trigger_events = []lexer.TokenType{lexer.TInsert, lexer.TUpdate, lexer.TDelete}
+
+func (p *Parser) parseCreateTriggerStatement(createKeywords []ast.Keyword) (*ast.CreateTriggerStatement, error) {
+	triggerKw, err := p.consumeKeyword(lexer.TTrigger)
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	onKw, err := p.consumeKeyword(lexer.TOn)
+	if err != nil {
+		return nil, err
+	}
+	table, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.CreateTriggerStatement{
+		CreateKeywords: createKeywords,
+		TriggerKeyword: *triggerKw,
+		Name:           name,
+		OnKeyword:      *onKw,
+		Table:          table,
+	}
+	stmt.WithKeyword, stmt.Options, err = p.maybeRoutineOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	if afterKw := p.maybeIdentifierKeyword("after"); afterKw != nil {
+		stmt.TimingKeywords = []ast.Keyword{*afterKw}
+	} else if forKw := p.maybeKeyword(lexer.TFor); forKw != nil {
+		stmt.TimingKeywords = []ast.Keyword{*forKw}
+	} else if insteadKw := p.maybeIdentifierKeyword("instead"); insteadKw != nil {
+		ofKw, err := p.consumeKeyword(lexer.TOf)
+		if err != nil {
+			return nil, err
+		}
+		stmt.TimingKeywords = []ast.Keyword{*insteadKw, *ofKw}
+	} else {
+		return nil, p.peekErrorString("AFTER, FOR or INSTEAD OF")
+	}
+
+	for {
+		eventKw, err := p.consumeKeywordAny(trigger_events)
+		if err != nil {
+			return nil, p.peekErrorMany(trigger_events)
+		}
+		stmt.EventKeywords = append(stmt.EventKeywords, *eventKw)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	asKw, err := p.consumeKeyword(lexer.TAs)
+	if err != nil {
+		return nil, err
+	}
+	stmt.AsKeyword = *asKw
+	stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, asKw.EndPosition)
+
+	// like a procedure the body of a trigger runs to the end of the batch
+	for !p.peekTokenIsAny(batch_end) {
+		if token := p.maybeToken(lexer.TSemiColon); token != nil {
+			continue
+		}
+
+		statement, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Statements = append(stmt.Statements, statement)
+		stmt.Span = ast.NewSpanFromLexerPosition(createKeywords[0].StartPosition, statement.GetSpan().EndPosition)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseRoutineParameters() ([]ast.RoutineParameter, error) {
+	parameters := []ast.RoutineParameter{}
+	for {
+		parameter, err := p.parseRoutineParameter()
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, *parameter)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return parameters, nil
+}
+
+func (p *Parser) parseRoutineParameter() (*ast.RoutineParameter, error) {
+	nameToken, err := p.consumeToken(lexer.TLocalVariable)
+	if err != nil {
+		return nil, err
+	}
+	parameter := &ast.RoutineParameter{
+		Name: ast.ExprLocalVariable{Value: nameToken.Value, Span: ast.NewSpanFromToken(*nameToken)},
+	}
+	parameter.AsKeyword = p.maybeKeyword(lexer.TAs)
+
+	var endPosition lexer.Position
+	if p.peekTokenIsAny(ast.DataTypeTokenTypes) {
+		parameter.DataType, err = p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+		endPosition = parameter.DataType.EndPosition
+	} else {
+		parameter.TypeName, err = p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		endPosition = parameter.TypeName.GetSpan().EndPosition
+	}
+
+	if token := p.maybeToken(lexer.TEqual); token != nil {
+		parameter.Default, err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		endPosition = parameter.Default.GetSpan().EndPosition
+	}
+
+	for {
+		optionKw := p.maybeKeyword(lexer.TOutput)
+		if optionKw == nil {
+			optionKw = p.maybeIdentifierKeyword("out")
+		}
+		if optionKw == nil {
+			optionKw = p.maybeIdentifierKeyword("readonly")
+		}
+		if optionKw == nil {
+			break
+		}
+		parameter.OptionKeywords = append(parameter.OptionKeywords, *optionKw)
+		endPosition = optionKw.EndPosition
+	}
+	parameter.Span = ast.NewSpanFromLexerPosition(nameToken.Start, endPosition)
+
+	return parameter, nil
+}
+
+var routine_options = []string{"recompile", "encryption", "schemabinding"}
+
+// parses WITH RECOMPILE, SCHEMABINDING, EXECUTE AS ...
+func (p *Parser) maybeRoutineOptions() (*ast.Keyword, []ast.RoutineOption, error) {
+	withKw := p.maybeKeyword(lexer.TWith)
+	if withKw == nil {
+		return nil, nil, nil
+	}
+
+	options := []ast.RoutineOption{}
+	for {
+		option, err := p.parseRoutineOption()
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, *option)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return withKw, options, nil
+}
+
+func (p *Parser) parseRoutineOption() (*ast.RoutineOption, error) {
+	for _, option := range routine_options {
+		if kw := p.maybeIdentifierKeyword(option); kw != nil {
+			return &ast.RoutineOption{Keywords: []ast.Keyword{*kw}, Span: kw.Span}, nil
+		}
+	}
+
+	executeKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TExec, lexer.TExecute})
+	if err != nil {
+		return nil, p.peekErrorString("RECOMPILE, ENCRYPTION, SCHEMABINDING or EXECUTE AS")
+	}
+	asKw, err := p.consumeKeyword(lexer.TAs)
+	if err != nil {
+		return nil, err
+	}
+	option := &ast.RoutineOption{Keywords: []ast.Keyword{*executeKw, *asKw}}
+
+	if token := p.maybeToken(lexer.TStringLiteral); token != nil {
+		option.Value = &ast.ExprStringLiteral{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+		option.Span = ast.NewSpanFromLexerPosition(executeKw.StartPosition, token.End)
+		return option, nil
+	}
+	for _, principal := range []string{"caller", "self", "owner"} {
+		if kw := p.maybeIdentifierKeyword(principal); kw != nil {
+			option.Keywords = append(option.Keywords, *kw)
+			option.Span = ast.NewSpanFromLexerPosition(executeKw.StartPosition, kw.EndPosition)
+			return option, nil
+		}
+	}
+
+	return nil, p.peekErrorString("CALLER, SELF, OWNER or a user name")
+}
+
+func (p *Parser) maybeIfExists() ([]ast.Keyword, error) {
+	ifKw := p.maybeKeyword(lexer.TIf)
+	if ifKw == nil {
+		return nil, nil
+	}
+	existsKw, err := p.consumeKeyword(lexer.TExists)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ast.Keyword{*ifKw, *existsKw}, nil
+}
+
+func (p *Parser) parseObjectNames() ([]ast.Expression, error) {
+	names := []ast.Expression{}
+	for {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return names, nil
+}
+
+// parses the parenthesized column definitions and table constraints of a table
+func (p *Parser) parseTableElements() ([]ast.TableElement, *lexer.Token, error) {
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, nil, err
+	}
+
+	definitions := []ast.TableElement{}
+	for {
+		definition, err := p.parseTableElement()
+		if err != nil {
+			return nil, nil, err
+		}
+		definitions = append(definitions, definition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return definitions, rightParen, nil
+}
+
+var table_constraint_start = []lexer.TokenType{
+	lexer.TConstraint,
+	lexer.TPrimary,
+	lexer.TUnique,
+	lexer.TForeign,
+	lexer.TCheck,
+}
+
+func (p *Parser) parseTableElement() (ast.TableElement, error) {
+	if p.peekTokenIsAny(table_constraint_start) {
+		return p.parseTableConstraint()
+	}
+
+	return p.parseColumnDefinition()
+}
+
+var column_constraint_start = []lexer.TokenType{
+	lexer.TConstraint,
+	lexer.TPrimary,
+	lexer.TUnique,
+	lexer.TForeign,
+	lexer.TReferences,
+	lexer.TCheck,
+	lexer.TDefault,
+}
+
+func (p *Parser) parseColumnDefinition() (*ast.ColumnDefinition, error) {
+	startPosition := p.peekToken.Start
+	var name ast.Expression
+	if token := p.maybeToken(lexer.TIdentifier); token != nil {
+		name = &ast.ExprIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
+		name = &ast.ExprQuotedIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else {
+		return nil, p.peekErrorMany([]lexer.TokenType{lexer.TIdentifier, lexer.TQuotedIdentifier})
+	}
+	column := &ast.ColumnDefinition{Name: name}
+
+	if asKw := p.maybeKeyword(lexer.TAs); asKw != nil {
+		value, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		column.AsKeyword = asKw
+		column.ComputedValue = value
+		column.Span = ast.NewSpanFromLexerPosition(startPosition, value.GetSpan().EndPosition)
+		if persistedKw := p.maybeIdentifierKeyword("persisted"); persistedKw != nil {
+			column.PersistedKeyword = persistedKw
+			column.Span = ast.NewSpanFromLexerPosition(startPosition, persistedKw.EndPosition)
+		}
+	} else {
+		dataType, err := p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+		column.DataType = dataType
+		column.Span = ast.NewSpanFromLexerPosition(startPosition, dataType.EndPosition)
+	}
+
+	for {
+		if p.peekTokenIs(lexer.TIdentity) {
+			identity, err := p.parseIdentityProperty()
+			if err != nil {
+				return nil, err
+			}
+			column.Identity = identity
+			column.Span = ast.NewSpanFromLexerPosition(startPosition, identity.EndPosition)
+		} else if notKw := p.maybeKeyword(lexer.TNot); notKw != nil {
+			nullKw, err := p.consumeKeyword(lexer.TNull)
+			if err != nil {
+				return nil, err
+			}
+			column.NullKeywords = []ast.Keyword{*notKw, *nullKw}
+			column.Span = ast.NewSpanFromLexerPosition(startPosition, nullKw.EndPosition)
+		} else if nullKw := p.maybeKeyword(lexer.TNull); nullKw != nil {
+			column.NullKeywords = []ast.Keyword{*nullKw}
+			column.Span = ast.NewSpanFromLexerPosition(startPosition, nullKw.EndPosition)
+		} else if p.peekTokenIsAny(column_constraint_start) {
+			constraint, err := p.parseTableConstraint()
+			if err != nil {
+				return nil, err
+			}
+			column.Constraints = append(column.Constraints, *constraint)
+			column.Span = ast.NewSpanFromLexerPosition(startPosition, constraint.EndPosition)
+		} else {
+			break
+		}
+	}
+
+	return column, nil
+}
+
+func (p *Parser) parseIdentityProperty() (*ast.IdentityProperty, error) {
+	identityKw, err := p.consumeKeyword(lexer.TIdentity)
+	if err != nil {
+		return nil, err
+	}
+	identity := &ast.IdentityProperty{IdentityKeyword: *identityKw, Span: identityKw.Span}
+	if token := p.maybeToken(lexer.TLeftParen); token == nil {
+		return identity, nil
+	}
+
+	seed, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TComma); err != nil {
+		return nil, err
+	}
+	increment, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	identity.Seed = seed
+	identity.Increment = increment
+	identity.Span = ast.NewSpanFromLexerPosition(identityKw.StartPosition, rightParen.End)
+
+	return identity, nil
+}
+
+// parses both column and table constraints, table constraints list their columns
+func (p *Parser) parseTableConstraint() (*ast.TableConstraint, error) {
+	startPosition := p.peekToken.Start
+	constraint := &ast.TableConstraint{}
+
+	if constraintKw := p.maybeKeyword(lexer.TConstraint); constraintKw != nil {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		constraint.ConstraintKeyword = constraintKw
+		constraint.Name = name
+	}
+
+	var endPosition lexer.Position
+	switch p.peekToken.Type {
+	case lexer.TPrimary:
+		primaryKw, _ := p.consumeKeyword(lexer.TPrimary)
+		keyKw, err := p.consumeKeyword(lexer.TKey)
+		if err != nil {
+			return nil, err
+		}
+		constraint.Type = ast.CTPrimaryKey
+		constraint.TypeKeywords = []ast.Keyword{*primaryKw, *keyKw}
+		endPosition = keyKw.EndPosition
+	case lexer.TUnique:
+		uniqueKw, _ := p.consumeKeyword(lexer.TUnique)
+		constraint.Type = ast.CTUnique
+		constraint.TypeKeywords = []ast.Keyword{*uniqueKw}
+		endPosition = uniqueKw.EndPosition
+	case lexer.TForeign:
+		foreignKw, _ := p.consumeKeyword(lexer.TForeign)
+		keyKw, err := p.consumeKeyword(lexer.TKey)
+		if err != nil {
+			return nil, err
+		}
+		constraint.Type = ast.CTForeignKey
+		constraint.TypeKeywords = []ast.Keyword{*foreignKw, *keyKw}
+		endPosition = keyKw.EndPosition
+	case lexer.TReferences:
+		constraint.Type = ast.CTForeignKey
+	case lexer.TCheck:
+		checkKw, _ := p.consumeKeyword(lexer.TCheck)
+		if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+			return nil, err
+		}
+		condition, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		constraint.Type = ast.CTCheck
+		constraint.TypeKeywords = []ast.Keyword{*checkKw}
+		constraint.Expression = condition
+		endPosition = rightParen.End
+	case lexer.TDefault:
+		defaultKw, _ := p.consumeKeyword(lexer.TDefault)
+		// stop before NOT so a following NOT NULL is left for the column
+		value, err := p.parseExpression(PrecedenceNot)
+		if err != nil {
+			return nil, err
+		}
+		constraint.Type = ast.CTDefault
+		constraint.TypeKeywords = []ast.Keyword{*defaultKw}
+		constraint.Expression = value
+		endPosition = value.GetSpan().EndPosition
+		if forKw := p.maybeKeyword(lexer.TFor); forKw != nil {
+			column, err := p.parseObjectName()
+			if err != nil {
+				return nil, err
+			}
+			constraint.ForKeyword = forKw
+			constraint.ForColumn = column
+			endPosition = column.GetSpan().EndPosition
+		}
+	default:
+		return nil, p.peekErrorMany(column_constraint_start[1:])
+	}
+
+	if constraint.Type == ast.CTPrimaryKey || constraint.Type == ast.CTUnique {
+		if clusteredKw := p.maybeKeyword(lexer.TClustered); clusteredKw != nil {
+			constraint.ClusteredKeyword = clusteredKw
+			endPosition = clusteredKw.EndPosition
+		} else if clusteredKw := p.maybeKeyword(lexer.TNonclustered); clusteredKw != nil {
+			constraint.ClusteredKeyword = clusteredKw
+			endPosition = clusteredKw.EndPosition
+		}
+	}
+	if constraint.Type != ast.CTCheck && constraint.Type != ast.CTDefault && p.peekTokenIs(lexer.TLeftParen) {
+		p.nextToken()
+		columns, err := p.parseOrderByArgs()
+		if err != nil {
+			return nil, err
+		}
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		constraint.Columns = columns
+		endPosition = rightParen.End
+	}
+	if constraint.Type == ast.CTForeignKey {
+		if err := p.parseForeignKeyReference(constraint); err != nil {
+			return nil, err
+		}
+		endPosition = constraint.EndPosition
+	}
+	constraint.Span = ast.NewSpanFromLexerPosition(startPosition, endPosition)
+
+	return constraint, nil
+}
+
+// parses REFERENCES table [(columns)] [ON DELETE action] [ON UPDATE action]
+func (p *Parser) parseForeignKeyReference(constraint *ast.TableConstraint) error {
+	referencesKw, err := p.consumeKeyword(lexer.TReferences)
+	if err != nil {
+		return err
+	}
+	table, err := p.parseObjectName()
+	if err != nil {
+		return err
+	}
+	constraint.ReferencesKeyword = referencesKw
+	constraint.ReferencedTable = table
+	constraint.EndPosition = table.GetSpan().EndPosition
+
+	if p.peekTokenIs(lexer.TLeftParen) {
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return err
+		}
+		constraint.ReferencedColumns = columns
+		constraint.EndPosition = columns.EndPosition
+	}
+
+	for p.peekTokenIs(lexer.TOn) {
+		onKw, _ := p.consumeKeyword(lexer.TOn)
+		eventKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TDelete, lexer.TUpdate})
+		if err != nil {
+			return err
+		}
+		keywords := []ast.Keyword{*onKw, *eventKw}
+
+		if kw := p.maybeKeyword(lexer.TCascade); kw != nil {
+			keywords = append(keywords, *kw)
+		} else if kw := p.maybeIdentifierKeyword("no"); kw != nil {
+			keywords = append(keywords, *kw)
+			actionKw := p.maybeIdentifierKeyword("action")
+			if actionKw == nil {
+				return p.peekErrorString("ACTION")
+			}
+			keywords = append(keywords, *actionKw)
+		} else if kw := p.maybeKeyword(lexer.TSet); kw != nil {
+			keywords = append(keywords, *kw)
+			valueKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TNull, lexer.TDefault})
+			if err != nil {
+				return err
+			}
+			keywords = append(keywords, *valueKw)
+		} else {
+			return p.peekErrorString("CASCADE, NO ACTION, SET NULL or SET DEFAULT")
+		}
+		constraint.EndPosition = keywords[len(keywords)-1].EndPosition
+
+		if eventKw.Type == ast.KDelete {
+			constraint.OnDeleteKeywords = keywords
+		} else {
+			constraint.OnUpdateKeywords = keywords
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"SequelGo/internal/ast"
 	"SequelGo/internal/lexer"
 	"fmt"
+	"strings"
 )
 
 func (p *Parser) parseExpression(precedence Precedence) (ast.Expression, error) {
@@ -12,6 +13,11 @@ func (p *Parser) parseExpression(precedence Precedence) (ast.Expression, error)
 		return nil, err
 	}
 
+	return p.parseInfixExpressions(leftExpr, precedence)
+}
+
+func (p *Parser) parseInfixExpressions(leftExpr ast.Expression, precedence Precedence) (ast.Expression, error) {
+	var err error
 	// parse infix sql expressions using stacks to keep track of precedence
 	for precedence < p.peekPrecedence() {
 		leftExpr, err = p.parseInfixExpression(leftExpr)
@@ -26,10 +32,45 @@ func (p *Parser) parseExpression(precedence Precedence) (ast.Expression, error)
 func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 	p.logger.Debugf("parsing prefix expression. peekToken %s", p.peekToken.String())
 	var newExpr ast.Expression
+
+	// try_cast, parse and try_parse are not reserved so they are lexed as identifiers
+	if p.peekTokenIs(lexer.TIdentifier) && p.peekSecondTokenIs(lexer.TLeftParen) {
+		switch strings.ToLower(p.peekToken.Value) {
+		case "try_cast":
+			expr, err := p.parseCast()
+			if err != nil {
+				return nil, err
+			}
+			return expr, nil
+		case "parse", "try_parse":
+			expr, err := p.parseParse()
+			if err != nil {
+				return nil, err
+			}
+			return expr, nil
+		}
+	}
+
+	// left, right and the rest are only functions when they are called
+	if p.peekTokenIsAny(keyword_functions) && p.peekSecondTokenIs(lexer.TLeftParen) {
+		function := &ast.ExprFunction{
+			Type: ast.FuncSystem,
+			Name: &ast.ExprBuiltInFunctionName{Value: p.peekToken.Value, Span: ast.NewSpanFromToken(p.peekToken)},
+			Span: ast.NewSpanFromToken(p.peekToken),
+		}
+		p.nextToken()
+		functionCall, err := p.parseFunctionCall(function)
+		if err != nil {
+			return nil, err
+		}
+		return functionCall, nil
+	}
+
 	switch p.peekToken.Type {
 	case lexer.TIdentifier,
 		lexer.TNumericLiteral,
 		lexer.TStringLiteral,
+		lexer.TNationalStringLiteral,
 		lexer.TAsterisk,
 		lexer.TLocalVariable,
 		lexer.TQuotedIdentifier:
@@ -49,6 +90,12 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 				Value: p.peekToken.Value,
 				Span:  ast.NewSpanFromToken(p.peekToken),
 			}
+		case lexer.TNationalStringLiteral:
+			newExpr = &ast.ExprStringLiteral{
+				Value:   p.peekToken.Value,
+				Unicode: true,
+				Span:    ast.NewSpanFromToken(p.peekToken),
+			}
 		case lexer.TNumericLiteral:
 			newExpr = &ast.ExprNumberLiteral{
 				Value: p.peekToken.Value,
@@ -86,13 +133,7 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 		// parsing user functions
 		if p.peekTokenIs(lexer.TLeftParen) {
 			p.logger.Debugln("parsing user defined function")
-			function := &ast.ExprFunction{
-				Type: ast.FuncUserDefined,
-				Name: newExpr,
-				Span: ast.NewSpanFromToken(p.peekToken),
-			}
-
-			functionCall, err := p.parseFunctionCall(function)
+			functionCall, err := p.parseFunctionCall(newFunction(newExpr))
 			if err != nil {
 				return nil, err
 			}
@@ -139,7 +180,9 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 		lexer.TVarp,
 		lexer.TGetdate,
 		lexer.TChecksum,
-		lexer.TNewId:
+		lexer.TNewId,
+		lexer.TGrouping,
+		lexer.TGroupingId:
 		functionCall, err := p.parseFunctionCall(nil)
 		if err != nil {
 			return nil, err
@@ -165,6 +208,7 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 			lexer.TLocalVariable,
 			lexer.TQuotedIdentifier,
 			lexer.TStringLiteral,
+			lexer.TNationalStringLiteral,
 			lexer.TNumericLiteral,
 		}) {
 			stmt, err := p.parseExpressionList()
@@ -242,6 +286,9 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 			Span:       ast.NewSpanFromLexerPosition(notKw.StartPosition, expr.GetSpan().EndPosition),
 		}
 		break
+	case lexer.TNull:
+		newExpr = &ast.ExprNullLiteral{Span: ast.NewSpanFromToken(p.peekToken)}
+		p.nextToken()
 	case lexer.TCast:
 		expr, err := p.parseCast()
 		if err != nil {
@@ -249,6 +296,20 @@ func (p *Parser) parsePrefixExpression() (ast.Expression, error) {
 		}
 		p.logger.Debug(expr.TokenLiteral())
 		newExpr = expr
+	case lexer.TConvert, lexer.TTryConvert:
+		expr, err := p.parseConvert()
+		if err != nil {
+			return nil, err
+		}
+		p.logger.Debug(expr.TokenLiteral())
+		newExpr = expr
+	case lexer.TCase:
+		expr, err := p.parseCase()
+		if err != nil {
+			return nil, err
+		}
+		p.logger.Debug(expr.TokenLiteral())
+		newExpr = expr
 	default:
 		return nil, p.peekErrorString(fmt.Sprintf("Unimplemented expression \"%s\"", p.peekToken.Type.String()))
 	}
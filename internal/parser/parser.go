@@ -58,6 +58,17 @@ func (p *Parser) peekTokenIsAny(t []lexer.TokenType) bool {
 	return false
 }
 
+// looks at the token after the peek token without consuming anything
+func (p *Parser) peekSecondTokenIs(t lexer.TokenType) bool {
+	l := *p.l
+	token := l.NextToken()
+	for token.Type == lexer.TCommentLine {
+		token = l.NextToken()
+	}
+
+	return token.Type == t
+}
+
 func (p *Parser) peekPrecedence() Precedence {
 	return checkPrecedence(p.peekToken.Type)
 }
@@ -142,6 +153,21 @@ func (p *Parser) maybeKeyword(t lexer.TokenType) *ast.Keyword {
 	return kw
 }
 
+// contextual keywords like TARGET and SOURCE are lexed as identifiers
+func (p *Parser) maybeIdentifierKeyword(value string) *ast.Keyword {
+	if p.peekToken.Type != lexer.TIdentifier || !strings.EqualFold(p.peekToken.Value, value) {
+		return nil
+	}
+
+	kw, err := ast.NewKeywordFromTokenNew(p.peekToken)
+	if err != nil {
+		return nil
+	}
+	p.nextToken()
+
+	return kw
+}
+
 func (p *Parser) maybeToken(t lexer.TokenType) *lexer.Token {
 	if p.peekToken.Type != t {
 		return nil
@@ -243,27 +269,92 @@ func (p *Parser) Parse() ast.Query {
 	queryStartPosition := p.peekToken.Start
 
 	for !p.peekTokenIs(lexer.TEndOfFile) {
-		startPosition := p.peekToken.Start
-		stmt, err := p.parseStatement()
-		endPosition := p.peekToken.End
+		query.Batches = append(query.Batches, p.parseBatch())
+	}
+	queryEndPosition := p.peekToken.End
+	query.SetSpan(ast.NewSpanFromLexerPosition(queryStartPosition, queryEndPosition))
+	return query
+}
+
+var batch_end = []lexer.TokenType{lexer.TGo, lexer.TEndOfFile}
 
+// parses the statements up to GO, errors are recovered from within the batch
+// so the batches after it are still parsed
+func (p *Parser) parseBatch() ast.Batch {
+	batch := ast.Batch{}
+	startPosition := p.peekToken.Start
+	endPosition := p.peekToken.Start
+
+	for !p.peekTokenIsAny(batch_end) {
+		if token := p.maybeToken(lexer.TSemiColon); token != nil {
+			continue
+		}
+
+		stmt, err := p.parseStatement()
 		if err != nil {
 			errMsg := fmt.Sprintf("[Error Line: %d Col: %d]: %s", p.peekToken.End.Line,
 				p.peekToken.End.Col+1, err.Error())
 			p.errors = append(p.errors, errMsg)
-			p.nextToken()
+			p.skipStatement()
 			continue
 		}
-		if stmt != nil {
-			stmt.SetSpan(ast.NewSpanFromLexerPosition(startPosition, endPosition))
-			query.Statements = append(query.Statements, stmt)
+		batch.Statements = append(batch.Statements, stmt)
+		endPosition = stmt.GetSpan().EndPosition
+
+		p.maybeToken(lexer.TSemiColon)
+	}
+
+	if goKw := p.maybeKeyword(lexer.TGo); goKw != nil {
+		batch.GoKeyword = goKw
+		endPosition = goKw.EndPosition
+		if p.peekTokenIs(lexer.TNumericLiteral) && p.peekToken.Start.Line == goKw.StartPosition.Line {
+			batch.Count = &ast.ExprNumberLiteral{Value: p.peekToken.Value, Span: ast.NewSpanFromToken(p.peekToken)}
+			endPosition = p.peekToken.End
+			p.nextToken()
 		}
+	}
+	batch.SetSpan(ast.NewSpanFromLexerPosition(startPosition, endPosition))
+
+	return batch
+}
 
+var statement_start = []lexer.TokenType{
+	lexer.TSelect,
+	lexer.TWith,
+	lexer.TInsert,
+	lexer.TUpdate,
+	lexer.TDelete,
+	lexer.TMerge,
+	lexer.TDeclare,
+	lexer.TBegin,
+	lexer.TIf,
+	lexer.TWhile,
+	lexer.TReturn,
+	lexer.TThrow,
+	lexer.TRaiserror,
+	lexer.TPrint,
+	lexer.TCommit,
+	lexer.TRollback,
+	lexer.TSave,
+	lexer.TExec,
+	lexer.TExecute,
+	lexer.TCreate,
+	lexer.TAlter,
+	lexer.TDrop,
+}
+
+// skip the rest of a statement that failed to parse so the next one can be parsed
+func (p *Parser) skipStatement() {
+	if p.peekTokenIs(lexer.TGo) {
+		return
+	}
+	p.nextToken()
+	for !p.peekTokenIsAny(statement_start) && !p.peekTokenIsAny(batch_end) {
+		if token := p.maybeToken(lexer.TSemiColon); token != nil {
+			return
+		}
 		p.nextToken()
 	}
-	queryEndPosition := p.peekToken.End
-	query.SetSpan(ast.NewSpanFromLexerPosition(queryStartPosition, queryEndPosition))
-	return query
 }
 
 var select_item_type_start = []lexer.TokenType{
@@ -271,7 +362,9 @@ var select_item_type_start = []lexer.TokenType{
 	lexer.TQuotedIdentifier,
 	lexer.TNumericLiteral,
 	lexer.TStringLiteral,
+	lexer.TNationalStringLiteral,
 	lexer.TLocalVariable,
+	lexer.TNull,
 	lexer.TLeftParen,
 	lexer.TCase,
 	lexer.TAsterisk,
@@ -283,6 +376,9 @@ func (p *Parser) expectSelectItemStart() error {
 	if p.peekToken.Type.IsBuiltinFunction() {
 		return nil
 	}
+	if p.peekTokenIsAny(keyword_functions) && p.peekSecondTokenIs(lexer.TLeftParen) {
+		return nil
+	}
 
 	for _, t := range select_item_type_start {
 		if p.peekToken.Type == t {
@@ -333,6 +429,7 @@ var expression_list_start = []lexer.TokenType{
 	lexer.TLocalVariable,
 	lexer.TNumericLiteral,
 	lexer.TStringLiteral,
+	lexer.TNationalStringLiteral,
 }
 
 func (p *Parser) expectExpressionListStart() error {
@@ -351,6 +448,16 @@ var function_args_start = append([]lexer.TokenType{
 	lexer.TLocalVariable,
 	lexer.TNumericLiteral,
 	lexer.TStringLiteral,
+	lexer.TNationalStringLiteral,
+	lexer.TNull,
+	lexer.TAsterisk,
+	lexer.TLeftParen,
+	lexer.TMinus,
+	lexer.TPlus,
+	lexer.TCase,
+	lexer.TCast,
+	lexer.TConvert,
+	lexer.TTryConvert,
 }, ast.BuiltinFunctionsTokenType...)
 
 func (p *Parser) expectFunctionArgsStart() error {
@@ -11,7 +11,7 @@ import (
 
 func TestParseBasicSelectQuery(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
@@ -44,7 +44,7 @@ func TestParseBasicSelectQuery(t *testing.T) {
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
 	input := "select *,\n hello,\n 'yes',\n [yessir],\n @nosir, [superdb].world.* FROM testtable where LastPrice < 10.0"
 
@@ -64,7 +64,7 @@ func TestParseBasicSelectQueryWithCte(t *testing.T) {
 					},
 				},
 				AsKeyword: ast.Keyword{Type: ast.KAs},
-				Query: ast.SelectBody{
+				Query: &ast.SelectBody{
 					SelectKeyword: ast.Keyword{Type: ast.KSelect},
 					SelectItems: ast.SelectItems{
 						Items: []ast.Expression{
@@ -85,7 +85,7 @@ func TestParseBasicSelectQueryWithCte(t *testing.T) {
 			{
 				Name:      "testctenamedos",
 				AsKeyword: ast.Keyword{Type: ast.KAs},
-				Query: ast.SelectBody{
+				Query: &ast.SelectBody{
 					SelectKeyword: ast.Keyword{Type: ast.KSelect},
 					SelectItems: ast.SelectItems{
 						Items: []ast.Expression{
@@ -103,7 +103,7 @@ func TestParseBasicSelectQueryWithCte(t *testing.T) {
 				},
 			},
 		},
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
@@ -136,7 +136,7 @@ func TestParseBasicSelectQueryWithCte(t *testing.T) {
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
 	input := "with testctename (LastPrice, PercentChange) as (select *, hello, 'yes' FROM testtable), testctenamedos as (select FirstName, LastName from Users) select *,\n hello,\n 'yes',\n [yessir],\n @nosir, [superdb].world.* FROM testtable where LastPrice < 10.0"
 
@@ -147,7 +147,7 @@ func TestParseBasicSelectQueryWithCast(t *testing.T) {
 	float := uint32(24)
 	floatPrecision := &float
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
@@ -189,7 +189,7 @@ func TestParseBasicSelectQueryWithCast(t *testing.T) {
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
 	input := "select *,\n hello,\n 'yes',\n [yessir],\n @nosir, [superdb].world.* FROM"
 	input += " testtable where LastPrice < cast('10' as float(24))"
@@ -199,7 +199,7 @@ func TestParseBasicSelectQueryWithCast(t *testing.T) {
 
 func TestParseBasicSelectQueryWithJoin(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
@@ -264,7 +264,7 @@ func TestParseBasicSelectQueryWithJoin(t *testing.T) {
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
 	input := "select *,\n hello,\n 'yes',\n [yessir],\n @nosir, [superdb].world.* FROM testtable t"
 	input += " inner join testtable2 t2 ON t.InsertDate = t2.InsertDate where LastPrice < 10.0"
@@ -272,346 +272,525 @@ func TestParseBasicSelectQueryWithJoin(t *testing.T) {
 	test(t, expected, input)
 }
 
-func TestParseBuiltinFunctionCall(t *testing.T) {
+func TestParseApplyOperators(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
-					&ast.ExprIdentifier{Value: "hello"},
-					&ast.ExprFunctionCall{
-						Name: &ast.ExprFunction{
-							Type: ast.FuncSum,
-							Name: &ast.ExprIdentifier{Value: "sum"},
-						},
-						Args: []ast.Expression{
-							&ast.ExprIdentifier{Value: "price"},
-						},
-						OverClause: &ast.FunctionOverClause{
-							OverKeyword: ast.Keyword{Type: ast.KOver},
-							PartitionByKeyword: &[2]ast.Keyword{
-								{Type: ast.KPartition},
-								{Type: ast.KBy},
-							},
-							PartitionByClause: []ast.Expression{
-								&ast.ExprIdentifier{Value: "InsertDate"},
-								&ast.ExprIdentifier{Value: "Stock"},
-							},
-							OrderByKeyword: &[2]ast.Keyword{
-								{Type: ast.KOrder},
-								{Type: ast.KBy},
-							},
-							OrderByClause: []ast.OrderByArg{
-								{
-									Column:       &ast.ExprIdentifier{Value: "InsertTime"},
-									OrderKeyword: &ast.Keyword{Type: ast.KAsc},
-									Type:         ast.OBAsc,
-								},
-							},
-							WindowFrameClause: &ast.WindowFrameClause{
-								RowsOrRangeKeyword: ast.Keyword{Type: ast.KRows},
-								RowsOrRange:        ast.RRTRows,
-								BetweenKeyword:     &ast.Keyword{Type: ast.KBetween},
-								Start: &ast.WindowFrameBound{
-									BoundKeyword: []ast.Keyword{
-										{Type: ast.KPreceding},
-									},
-									Type:       ast.WFBTPreceding,
-									Expression: &ast.ExprNumberLiteral{Value: "10"},
-								},
-								AndKeyword: &ast.Keyword{Type: ast.KAnd},
-								End: &ast.WindowFrameBound{
-									BoundKeyword: []ast.Keyword{
-										{Type: ast.KCurrent},
-										{Type: ast.KRow},
-									},
-									Type: ast.WFBTCurrentRow,
-								},
-							},
-						},
-					},
+					&ast.ExprIdentifier{Value: "Price"},
 				},
 			},
 			Table: &ast.TableArg{
 				FromKeyword: ast.Keyword{Type: ast.KFrom},
 				Table: &ast.TableSource{
-					Type:   ast.TSTTable,
-					Source: &ast.ExprIdentifier{Value: "testtable"},
+					Type: ast.TSTTable,
+					Source: &ast.ExprWithAlias{
+						Expression: &ast.ExprIdentifier{Value: "Stocks"},
+						Alias:      &ast.ExprIdentifier{Value: "s"},
+					},
+				},
+				Joins: []ast.Join{
+					{
+						JoinTypeKeyword: []ast.Keyword{{Type: ast.KOuter}, {Type: ast.KApply}},
+						Type:            ast.JTOuterApply,
+						Table: &ast.TableSource{
+							Type: ast.TSTDerived,
+							Source: &ast.ExprWithAlias{
+								Expression: &ast.ExprSubquery{Query: &ast.SelectBody{
+									SelectKeyword: ast.Keyword{Type: ast.KSelect},
+									SelectItems: ast.SelectItems{
+										Items: []ast.Expression{
+											&ast.ExprIdentifier{Value: "Price"},
+										},
+									},
+									Table: &ast.TableArg{
+										FromKeyword: ast.Keyword{Type: ast.KFrom},
+										Table: &ast.TableSource{
+											Type:   ast.TSTTable,
+											Source: &ast.ExprIdentifier{Value: "Quotes"},
+										},
+									},
+								}},
+								AsKeyword: &ast.Keyword{Type: ast.KAs},
+								Alias:     &ast.ExprIdentifier{Value: "q"},
+							},
+						},
+					},
 				},
 			},
-		}}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select hello, sum(price) over(Partition by InsertDate, Stock Order by InsertTime asc rows between 10 preceding  and current row) FROM testtable"
+	input := "select Price from Stocks s outer apply (select Price from Quotes) as q"
 
 	test(t, expected, input)
 }
 
-func TestParseOrderByClause(t *testing.T) {
+func TestParsePivotOperators(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
-					&ast.ExprIdentifier{Value: "Stock"},
-					&ast.ExprIdentifier{Value: "PercentChange"},
+					&ast.ExprQuotedIdentifier{Value: "Jan"},
 				},
 			},
 			Table: &ast.TableArg{
 				FromKeyword: ast.Keyword{Type: ast.KFrom},
 				Table: &ast.TableSource{
-					Type:   ast.TSTTable,
-					Source: &ast.ExprIdentifier{Value: "MarketData"},
-				},
-			},
-			OrderByClause: &ast.OrderByClause{
-				OrderByKeyword: [2]ast.Keyword{
-					{Type: ast.KOrder},
-					{Type: ast.KBy},
-				},
-				Expressions: []ast.OrderByArg{
-					{
-						Column:       &ast.ExprIdentifier{Value: "InsertDate"},
-						Type:         ast.OBDesc,
-						OrderKeyword: &ast.Keyword{Type: ast.KDesc},
-					},
-					{
-						Column:       &ast.ExprIdentifier{Value: "InsertTime"},
-						Type:         ast.OBAsc,
-						OrderKeyword: &ast.Keyword{Type: ast.KAsc},
-					},
-					{
-						Column: &ast.ExprIdentifier{Value: "Stock"},
-					},
-				},
-				OffsetFetch: &ast.OffsetFetchClause{
-					Offset: ast.OffsetArg{
-						OffsetKeyword:    ast.Keyword{Type: ast.KOffset},
-						Value:            &ast.ExprNumberLiteral{Value: "4"},
-						RowOrRows:        ast.RRRow,
-						RowOrRowsKeyword: ast.Keyword{Type: ast.KRow},
-					},
-					Fetch: &ast.FetchArg{
-						FetchKeyword:       ast.Keyword{Type: ast.KFetch},
-						Value:              &ast.ExprNumberLiteral{Value: "20"},
-						NextOrFirst:        ast.NFFirst,
-						RowOrRows:          ast.RRRows,
-						NextOrFirstKeyword: ast.Keyword{Type: ast.KFirst},
-						RowOrRowsKeyword:   ast.Keyword{Type: ast.KRows},
-						OnlyKeyword:        ast.Keyword{Type: ast.KOnly},
+					Type: ast.TSTPivot,
+					Source: &ast.ExprWithAlias{
+						Expression: &ast.ExprPivot{
+							Source:       &ast.ExprIdentifier{Value: "Sales"},
+							PivotKeyword: ast.Keyword{Type: ast.KPivot},
+							Aggregate: &ast.ExprFunctionCall{
+								Name: &ast.ExprFunction{
+									Type: ast.FuncSum,
+									Name: &ast.ExprIdentifier{Value: "SUM"},
+								},
+								Args: []ast.Expression{
+									&ast.ExprIdentifier{Value: "Amount"},
+								},
+							},
+							ForKeyword: ast.Keyword{Type: ast.KFor},
+							Column:     &ast.ExprIdentifier{Value: "month"},
+							InKeyword:  ast.Keyword{Type: ast.KIn},
+							Values: []ast.Expression{
+								&ast.ExprQuotedIdentifier{Value: "Jan"},
+								&ast.ExprQuotedIdentifier{Value: "Feb"},
+							},
+						},
+						AsKeyword: &ast.Keyword{Type: ast.KAs},
+						Alias:     &ast.ExprIdentifier{Value: "p"},
 					},
 				},
 			},
-		}}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select Stock, PercentChange FROM MarketData order by InsertDate Desc, InsertTime asc"
-	input += ", Stock offset 4 row fetch first 20 rows only"
+	input := "select [Jan] from Sales pivot (sum(Amount) for month in ([Jan], [Feb])) as p"
 
 	test(t, expected, input)
 }
 
-func TestParseSubqueryCall(t *testing.T) {
+func TestParseTableAndQueryHints(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
-					&ast.ExprIdentifier{Value: "hello"},
-					&ast.ExprWithAlias{
-						Expression: &ast.ExprSubquery{
-							SelectBody: ast.SelectBody{
-								SelectKeyword: ast.Keyword{Type: ast.KSelect},
-								Top: &ast.TopArg{
-									TopKeyword:     ast.Keyword{Type: ast.KTop},
-									PercentKeyword: &ast.Keyword{Type: ast.KPercent},
-									Quantity:       &ast.ExprNumberLiteral{Value: "20"},
-								},
-								SelectItems: ast.SelectItems{
-									Items: []ast.Expression{
-										&ast.ExprIdentifier{Value: "yesirr"},
-									},
-								},
-								Table: &ast.TableArg{
-									FromKeyword: ast.Keyword{Type: ast.KFrom},
-									Table: &ast.TableSource{
-										Type:   ast.TSTTable,
-										Source: &ast.ExprIdentifier{Value: "bruh"},
-									},
-								},
-								WhereClause: &ast.WhereClause{
-									WhereKeyword: ast.Keyword{Type: ast.KWhere},
-									Clause: &ast.ExprComparisonOperator{
-										Left: &ast.ExprIdentifier{
-											Value: "LastPrice",
-										},
-										Operator: ast.ComparisonOpLess,
-										Right:    &ast.ExprNumberLiteral{Value: "10.0"}},
-								},
-								OrderByClause: &ast.OrderByClause{
-									OrderByKeyword: [2]ast.Keyword{
-										{Type: ast.KOrder},
-										{Type: ast.KBy},
-									},
-									Expressions: []ast.OrderByArg{
-										{
-											Column:       &ast.ExprIdentifier{Value: "LastPrice"},
-											OrderKeyword: &ast.Keyword{Type: ast.KDesc},
-											Type:         ast.OBDesc,
-										},
-									},
-								},
-							},
-						},
-						Alias: &ast.ExprIdentifier{Value: "NetScore"},
-					},
+					&ast.ExprIdentifier{Value: "Symbol"},
 				},
 			},
 			Table: &ast.TableArg{
 				FromKeyword: ast.Keyword{Type: ast.KFrom},
 				Table: &ast.TableSource{
-					Type:   ast.TSTTable,
-					Source: &ast.ExprIdentifier{Value: "testtable"},
+					Type:        ast.TSTTable,
+					Source:      &ast.ExprIdentifier{Value: "Quotes"},
+					WithKeyword: &ast.Keyword{Type: ast.KWith},
+					Hints: []ast.TableHint{
+						{HintKeyword: ast.Keyword{Type: ast.KNolock}},
+						{
+							HintKeyword: ast.Keyword{Type: ast.KIndex},
+							Indexes:     []ast.Expression{&ast.ExprIdentifier{Value: "IX_Quotes"}},
+						},
+					},
+				},
+			},
+			OptionClause: &ast.OptionClause{
+				OptionKeyword: ast.Keyword{Type: ast.KOption},
+				Hints: []ast.QueryHint{
+					{Keywords: []ast.Keyword{{Type: ast.KRecompile}}},
+					{
+						Keywords: []ast.Keyword{{Type: ast.KMaxdop}},
+						Value:    &ast.ExprNumberLiteral{Value: "1"},
+					},
+					{
+						Keywords: []ast.Keyword{{Type: ast.KOptimize}, {Type: ast.KFor}},
+						Variables: []ast.OptimizeForVariable{
+							{
+								Variable:       ast.ExprLocalVariable{Value: "Symbol"},
+								UnknownKeyword: &ast.Keyword{Type: ast.KUnknown},
+							},
+						},
+					},
 				},
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select hello,  (select  top 20 percent yesirr from bruh where LastPrice < 10.0"
-	input += " order by LastPrice desc) NetScore FROM testtable"
+	input := "select Symbol from Quotes with (nolock, index(IX_Quotes)) option (recompile, maxdop 1, optimize for (@Symbol unknown))"
 
 	test(t, expected, input)
 }
 
-func TestParseSomeLogicalOperators(t *testing.T) {
+func TestParseTableHintsOnDmlTargets(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	input := "insert into Quotes with (tablock) (Symbol) values ('AAL');"
+	input += " update Quotes with (rowlock, readuncommitted) set Price = 11 where Symbol = 'AAL';"
+	input += " delete from Quotes with (forceseek(IX_Quotes (Symbol))) where Symbol = 'AAL';"
+	input += " merge into Quotes with (holdlock) as t using Staging s on t.Symbol = s.Symbol when matched then delete;"
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("expected no errors, got %s", strings.Join(p.Errors(), "\n"))
+	}
+	statements := query.Batches[0].Statements
+	if len(statements) != 4 {
+		t.Fatalf("expected 4 statements, got %d", len(statements))
+	}
+
+	merge := statements[3].(*ast.MergeStatement)
+	alias, ok := merge.Target.(*ast.ExprWithAlias)
+	if !ok {
+		t.Fatalf("expected merge target to have an alias, got %s", merge.Target.TokenLiteral())
+	}
+	targets := []ast.Expression{
+		statements[0].(*ast.InsertStatement).Target,
+		statements[1].(*ast.UpdateStatement).Target,
+		statements[2].(*ast.DeleteStatement).Target,
+		alias.Expression,
+	}
+	expectedHints := []string{"Tablock", "Rowlock, Readuncommitted", "Forceseek(IX_Quotes (Symbol))", "Holdlock"}
+	for i, target := range targets {
+		tableSource, ok := target.(*ast.TableSource)
+		if !ok {
+			t.Fatalf("expected target %d to be a table source, got %s", i, target.TokenLiteral())
+		}
+		hints := []string{}
+		for _, h := range tableSource.Hints {
+			hints = append(hints, h.TokenLiteral())
+		}
+		if strings.Join(hints, ", ") != expectedHints[i] {
+			t.Fatalf("expected hints %s, got %s", expectedHints[i], strings.Join(hints, ", "))
+		}
+	}
+}
+
+func TestParseSelectInto(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
-					&ast.ExprIdentifier{Value: "Stock"},
-					&ast.ExprIdentifier{Value: "LastPrice"},
+					&ast.ExprIdentifier{Value: "Symbol"},
+					&ast.ExprIdentifier{Value: "Price"},
 				},
 			},
+			IntoClause: &ast.IntoClause{
+				IntoKeyword: ast.Keyword{Type: ast.KInto},
+				Target:      &ast.ExprIdentifier{Value: "#Prices"},
+			},
 			Table: &ast.TableArg{
 				FromKeyword: ast.Keyword{Type: ast.KFrom},
 				Table: &ast.TableSource{
 					Type:   ast.TSTTable,
-					Source: &ast.ExprIdentifier{Value: "MarketData"},
+					Source: &ast.ExprIdentifier{Value: "##Quotes"},
 				},
 			},
 			WhereClause: &ast.WhereClause{
 				WhereKeyword: ast.Keyword{Type: ast.KWhere},
-				Clause: &ast.ExprOrLogicalOperator{
-					OrKeyword: ast.Keyword{Type: ast.KOr},
-					Left: &ast.ExprAndLogicalOperator{
-						AndKeyword: ast.Keyword{Type: ast.KAnd},
-						Left: &ast.ExprComparisonOperator{
-							Left: &ast.ExprQuotedIdentifier{
-								Value: "LastPrice",
-							},
-							Operator: ast.ComparisonOpLess,
-							Right:    &ast.ExprNumberLiteral{Value: "10.0"}},
-						Right: &ast.ExprInLogicalOperator{
-							InKeyword:      ast.Keyword{Type: ast.KIn},
-							TestExpression: &ast.ExprIdentifier{Value: "Stock"},
-							NotKeyword:     &ast.Keyword{Type: ast.KNot},
-							Expressions: []ast.Expression{
-								&ast.ExprStringLiteral{Value: "AAL"},
-								&ast.ExprStringLiteral{Value: "AMZN"},
-								&ast.ExprStringLiteral{Value: "GOOGL"},
-								&ast.ExprStringLiteral{Value: "ZM"},
-							},
-						},
-					},
-					Right: &ast.ExprBetweenLogicalOperator{
-						BetweenKeyword: ast.Keyword{Type: ast.KBetween},
-						TestExpression: &ast.ExprIdentifier{Value: "PercentChange"},
-						Begin:          &ast.ExprNumberLiteral{Value: "1"},
-						AndKeyword:     ast.Keyword{Type: ast.KAnd},
-						End:            &ast.ExprNumberLiteral{Value: "4"},
-					},
+				Clause: &ast.ExprComparisonOperator{
+					Left:     &ast.ExprIdentifier{Value: "Price"},
+					Operator: ast.ComparisonOpGreater,
+					Right:    &ast.ExprNumberLiteral{Value: "1"},
 				},
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select Stock,  LastPrice FROM MarketData"
-	input += " where [LastPrice] < 10.0 and Stock nOT in ('AAL', 'AMZN', 'GOOGL', 'ZM')"
-	input += "\n or PercentChange Between 1 and 4"
+	input := "select Symbol, Price into #Prices from ##Quotes where Price > 1"
 
 	test(t, expected, input)
+
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	p := NewParser(logger.Sugar(), lexer.NewLexer("select Symbol into @Prices from Quotes"))
+	p.Parse()
+
+	if len(p.Errors()) == 0 {
+		t.Fatalf("expected an error for select into a table variable")
+	}
 }
 
-func TestParseSelectItemWithAlias(t *testing.T) {
+func TestParseDerivedTableColumns(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
+		Query: &ast.SelectBody{
 			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
-				Items: []ast.Expression{
-					&ast.ExprIdentifier{Value: "hello"},
-					&ast.ExprWithAlias{
-						Expression: &ast.ExprIdentifier{Value: "potate"},
-						Alias:      &ast.ExprStringLiteral{Value: "Potate"},
-					},
-					&ast.ExprSubquery{
-						SelectBody: ast.SelectBody{
-							SelectKeyword: ast.Keyword{Type: ast.KSelect},
-							SelectItems: ast.SelectItems{
-								Items: []ast.Expression{
-									&ast.ExprWithAlias{
-										Expression: &ast.ExprIdentifier{Value: "dt"},
-										AsKeyword:  &ast.Keyword{Type: ast.KAs},
-										Alias:      &ast.ExprQuotedIdentifier{Value: "Datetime"},
+				Items: []ast.Expression{&ast.ExprStar{}},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type: ast.TSTDerived,
+					Source: &ast.ExprWithAlias{
+						Expression: &ast.ExprValuesTable{
+							TableValueConstructor: ast.TableValueConstructor{
+								ValuesKeyword: ast.Keyword{Type: ast.KValues},
+								Rows: []ast.ExprExpressionList{
+									{
+										List: []ast.Expression{
+											&ast.ExprNumberLiteral{Value: "1"},
+											&ast.ExprStringLiteral{Value: "a"},
+										},
+									},
+									{
+										List: []ast.Expression{
+											&ast.ExprNumberLiteral{Value: "2"},
+											&ast.ExprStringLiteral{Value: "b"},
+										},
 									},
 								},
 							},
-							Table: &ast.TableArg{
-								FromKeyword: ast.Keyword{Type: ast.KFrom},
-								Table: &ast.TableSource{
-									Type:   ast.TSTTable,
-									Source: &ast.ExprIdentifier{Value: "bruh"},
+						},
+						AsKeyword: &ast.Keyword{Type: ast.KAs},
+						Alias:     &ast.ExprIdentifier{Value: "v"},
+					},
+					Columns: &ast.ExprExpressionList{
+						List: []ast.Expression{
+							&ast.ExprIdentifier{Value: "id"},
+							&ast.ExprIdentifier{Value: "name"},
+						},
+					},
+				},
+				Joins: []ast.Join{
+					{
+						JoinTypeKeyword: []ast.Keyword{{Type: ast.KInner}, {Type: ast.KJoin}},
+						Type:            ast.JTInner,
+						Table: &ast.TableSource{
+							Type: ast.TSTDerived,
+							Source: &ast.ExprWithAlias{
+								Expression: &ast.ExprSubquery{
+									Query: &ast.SelectBody{
+										SelectKeyword: ast.Keyword{Type: ast.KSelect},
+										SelectItems: ast.SelectItems{
+											Items: []ast.Expression{&ast.ExprNumberLiteral{Value: "1"}},
+										},
+									},
 								},
+								Alias: &ast.ExprIdentifier{Value: "d"},
+							},
+							Columns: &ast.ExprExpressionList{
+								List: []ast.Expression{&ast.ExprIdentifier{Value: "one"}},
 							},
 						},
-					}},
+						OnKeyword: &ast.Keyword{Type: ast.KOn},
+						Condition: &ast.ExprComparisonOperator{
+							Left: &ast.ExprCompoundIdentifier{
+								Identifiers: []ast.Expression{
+									&ast.ExprIdentifier{Value: "d"},
+									&ast.ExprIdentifier{Value: "one"},
+								},
+							},
+							Operator: ast.ComparisonOpEqual,
+							Right: &ast.ExprCompoundIdentifier{
+								Identifiers: []ast.Expression{
+									&ast.ExprIdentifier{Value: "v"},
+									&ast.ExprIdentifier{Value: "id"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select * from (values (1, 'a'), (2, 'b')) as v(id, name) inner join (select 1) d(one) on d.one = v.id"
+
+	test(t, expected, input)
+}
+
+func TestParseGroupingSets(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "Symbol"},
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncGroupingId,
+							Name: &ast.ExprBuiltInFunctionName{Value: "grouping_id"},
+						},
+						Args: []ast.Expression{
+							&ast.ExprIdentifier{Value: "Symbol"},
+							&ast.ExprIdentifier{Value: "Exchange"},
+						},
+					},
+				},
 			},
 			Table: &ast.TableArg{
 				FromKeyword: ast.Keyword{Type: ast.KFrom},
 				Table: &ast.TableSource{
 					Type:   ast.TSTTable,
-					Source: &ast.ExprIdentifier{Value: "testtable"},
+					Source: &ast.ExprIdentifier{Value: "Trades"},
+				},
+			},
+			GroupByClause: &ast.GroupByClause{
+				GroupByKeyword: [2]ast.Keyword{{Type: ast.KGroup}, {Type: ast.KBy}},
+				Items: []ast.Expression{
+					&ast.ExprGrouping{
+						Type:     ast.GTGroupingSets,
+						Keywords: []ast.Keyword{{Type: ast.KGrouping}, {Type: ast.KSets}},
+						Sets: []ast.Expression{
+							&ast.ExprGroupingSet{
+								Items: []ast.Expression{
+									&ast.ExprIdentifier{Value: "Symbol"},
+									&ast.ExprIdentifier{Value: "Exchange"},
+								},
+							},
+							&ast.ExprGroupingSet{},
+							&ast.ExprGrouping{
+								Type:     ast.GTRollup,
+								Keywords: []ast.Keyword{{Type: ast.KRollup}},
+								Sets:     []ast.Expression{&ast.ExprIdentifier{Value: "Symbol"}},
+							},
+						},
+					},
+					&ast.ExprGrouping{
+						Type:     ast.GTCube,
+						Keywords: []ast.Keyword{{Type: ast.KCube}},
+						Sets:     []ast.Expression{&ast.ExprIdentifier{Value: "Exchange"}},
+					},
 				},
 			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select hello, potate 'Potate', (select dt as [Datetime] from bruh) FROM testtable"
+	input := "select Symbol, grouping_id(Symbol, Exchange) from Trades"
+	input += " group by grouping sets ((Symbol, Exchange), (), rollup (Symbol)), cube (Exchange)"
 
 	test(t, expected, input)
 }
 
-func TestDistinctTopArg(t *testing.T) {
+func TestParseForClauseAndRowsetFunctions(t *testing.T) {
 	select_statement := ast.SelectStatement{
-		SelectBody: &ast.SelectBody{
-			SelectKeyword:   ast.Keyword{Type: ast.KSelect},
-			DistinctKeyword: &ast.Keyword{Type: ast.KDistinct},
-			Top: &ast.TopArg{
-				TopKeyword:     ast.Keyword{Type: ast.KTop},
-				PercentKeyword: &ast.Keyword{Type: ast.KPercent},
-				Quantity: &ast.ExprNumberLiteral{
-					Value: "44",
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{&ast.ExprIdentifier{Value: "id"}},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type: ast.TSTTableValuedFunction,
+					Source: &ast.ExprWithAlias{
+						Expression: &ast.ExprRowsetFunction{
+							FunctionKeyword: ast.Keyword{Type: ast.KOpenjson},
+							Args: []ast.Expression{
+								&ast.ExprLocalVariable{Value: "json"},
+								&ast.ExprStringLiteral{Value: "$.items"},
+							},
+							WithKeyword: &ast.Keyword{Type: ast.KWith},
+							Columns: []ast.RowsetColumn{
+								{
+									Name:     &ast.ExprIdentifier{Value: "id"},
+									DataType: ast.DataType{Kind: ast.DTInt},
+									Path:     &ast.ExprStringLiteral{Value: "$.id"},
+								},
+								{
+									Name:          &ast.ExprIdentifier{Value: "tags"},
+									DataType:      ast.DataType{Kind: ast.DTNvarchar, MaxLength: true},
+									Path:          &ast.ExprStringLiteral{Value: "$.tags"},
+									AsJsonKeyword: &[2]ast.Keyword{{Type: ast.KAs}, {Type: ast.KJson}},
+								},
+							},
+						},
+						AsKeyword: &ast.Keyword{Type: ast.KAs},
+						Alias:     &ast.ExprIdentifier{Value: "j"},
+					},
+				},
+			},
+			ForClause: &ast.ForClause{
+				ForKeyword:  ast.Keyword{Type: ast.KFor},
+				ModeKeyword: ast.Keyword{Type: ast.KJson},
+				Options: []ast.ForOption{
+					{Keywords: []ast.Keyword{{Type: ast.KPath}}},
+					{
+						Keywords: []ast.Keyword{{Type: ast.KRoot}},
+						Value:    &ast.ExprStringLiteral{Value: "data"},
+					},
+					{Keywords: []ast.Keyword{{Type: ast.KWithoutArrayWrapper}}},
 				},
 			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select id from openjson(@json, '$.items') with (id int '$.id', tags nvarchar(max) '$.tags' as json) as j"
+	input += " for json path, root('data'), without_array_wrapper"
+
+	test(t, expected, input)
+}
+
+func TestParseBuiltinFunctionCall(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
 			SelectItems: ast.SelectItems{
 				Items: []ast.Expression{
 					&ast.ExprIdentifier{Value: "hello"},
-					&ast.ExprWithAlias{
-						Expression: &ast.ExprIdentifier{Value: "potate"},
-						Alias:      &ast.ExprIdentifier{Value: "Potate"},
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncSum,
+							Name: &ast.ExprIdentifier{Value: "sum"},
+						},
+						Args: []ast.Expression{
+							&ast.ExprIdentifier{Value: "price"},
+						},
+						OverClause: &ast.FunctionOverClause{
+							OverKeyword: ast.Keyword{Type: ast.KOver},
+							PartitionByKeyword: &[2]ast.Keyword{
+								{Type: ast.KPartition},
+								{Type: ast.KBy},
+							},
+							PartitionByClause: []ast.Expression{
+								&ast.ExprIdentifier{Value: "InsertDate"},
+								&ast.ExprIdentifier{Value: "Stock"},
+							},
+							OrderByKeyword: &[2]ast.Keyword{
+								{Type: ast.KOrder},
+								{Type: ast.KBy},
+							},
+							OrderByClause: []ast.OrderByArg{
+								{
+									Column:       &ast.ExprIdentifier{Value: "InsertTime"},
+									OrderKeyword: &ast.Keyword{Type: ast.KAsc},
+									Type:         ast.OBAsc,
+								},
+							},
+							WindowFrameClause: &ast.WindowFrameClause{
+								RowsOrRangeKeyword: ast.Keyword{Type: ast.KRows},
+								RowsOrRange:        ast.RRTRows,
+								BetweenKeyword:     &ast.Keyword{Type: ast.KBetween},
+								Start: &ast.WindowFrameBound{
+									BoundKeyword: []ast.Keyword{
+										{Type: ast.KPreceding},
+									},
+									Type:       ast.WFBTPreceding,
+									Expression: &ast.ExprNumberLiteral{Value: "10"},
+								},
+								AndKeyword: &ast.Keyword{Type: ast.KAnd},
+								End: &ast.WindowFrameBound{
+									BoundKeyword: []ast.Keyword{
+										{Type: ast.KCurrent},
+										{Type: ast.KRow},
+									},
+									Type: ast.WFBTCurrentRow,
+								},
+							},
+						},
 					},
 				},
 			},
@@ -622,29 +801,1711 @@ func TestDistinctTopArg(t *testing.T) {
 					Source: &ast.ExprIdentifier{Value: "testtable"},
 				},
 			},
+		}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select hello, sum(price) over(Partition by InsertDate, Stock Order by InsertTime asc rows between 10 preceding  and current row) FROM testtable"
+
+	test(t, expected, input)
+}
+
+func TestParseGenericFunctionCall(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncUserDefined,
+							Name: &ast.ExprCompoundIdentifier{
+								Identifiers: []ast.Expression{
+									&ast.ExprIdentifier{Value: "dbo"},
+									&ast.ExprIdentifier{Value: "fn_Tax"},
+								},
+							},
+						},
+						Args: []ast.Expression{&ast.ExprIdentifier{Value: "Price"}},
+					},
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncSystem,
+							Name: &ast.ExprBuiltInFunctionName{Value: "dateadd"},
+						},
+						Args: []ast.Expression{
+							&ast.ExprDatePart{Value: "day"},
+							&ast.ExprNumberLiteral{Value: "1"},
+							&ast.ExprIdentifier{Value: "TradeDate"},
+						},
+					},
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncSystem,
+							Name: &ast.ExprBuiltInFunctionName{Value: "string_agg"},
+						},
+						Args: []ast.Expression{
+							&ast.ExprIdentifier{Value: "Symbol"},
+							&ast.ExprStringLiteral{Value: ","},
+						},
+						WithinGroupClause: &ast.WithinGroupClause{
+							WithinGroupKeyword: [2]ast.Keyword{{Type: ast.KWithin}, {Type: ast.KGroup}},
+							OrderByKeyword:     [2]ast.Keyword{{Type: ast.KOrder}, {Type: ast.KBy}},
+							OrderByClause: []ast.OrderByArg{
+								{
+									Column:       &ast.ExprIdentifier{Value: "Symbol"},
+									OrderKeyword: &ast.Keyword{Type: ast.KDesc},
+								},
+							},
+						},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "Trades"},
+				},
+			},
 		},
 	}
-	expected := ast.Query{Statements: []ast.Statement{&select_statement}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
 
-	input := "select distinct top 44 percent hello, potate Potate FROM testtable -- hello lmao"
+	input := "select dbo.fn_Tax(Price), dateadd(day, 1, TradeDate), string_agg(Symbol, ',') within group (order by Symbol desc)"
+	input += " from Trades"
 
 	test(t, expected, input)
 }
 
-func test(t *testing.T, expected ast.Query, input string) {
+func TestParseSystemFunctionType(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	defer logger.Sync()
-	sugar := logger.Sugar()
-	l := lexer.NewLexer(input)
-	p := NewParser(sugar, l)
+	input := "select upper(Symbol), nullif(Price, 0), year(d), month(d), day(d), left(Symbol, 2), right(Symbol, 1),"
+	input += " char(65), isnull(Note, ''), dbo.fn_Tax(Price) from Quotes"
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
 	query := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected errors %v", p.Errors())
+	}
+
+	items := query.Batches[0].Statements[0].(*ast.SelectStatement).Query.(*ast.SelectBody).SelectItems.Items
+	for i, item := range items {
+		function := item.(*ast.ExprFunctionCall).Name
+		expected := ast.FuncSystem
+		if i == len(items)-1 {
+			expected = ast.FuncUserDefined
+		}
+		if function.Type != expected {
+			t.Fatalf("expected function type %d for %s, got %d", expected, function.TokenLiteral(), function.Type)
+		}
+	}
+}
+
+func TestParseTableValuedFunctionAliasBeforeGroupBy(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprCompoundIdentifier{
+						Identifiers: []ast.Expression{
+							&ast.ExprIdentifier{Value: "f"},
+							&ast.ExprIdentifier{Value: "Symbol"},
+						},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type: ast.TSTTableValuedFunction,
+					Source: &ast.ExprWithAlias{
+						Expression: &ast.ExprFunctionCall{
+							Name: &ast.ExprFunction{
+								Type: ast.FuncUserDefined,
+								Name: &ast.ExprCompoundIdentifier{
+									Identifiers: []ast.Expression{
+										&ast.ExprIdentifier{Value: "dbo"},
+										&ast.ExprIdentifier{Value: "fn_Trades"},
+									},
+								},
+							},
+							Args: []ast.Expression{&ast.ExprNumberLiteral{Value: "1"}},
+						},
+						Alias: &ast.ExprIdentifier{Value: "f"},
+					},
+				},
+			},
+			GroupByClause: &ast.GroupByClause{
+				GroupByKeyword: [2]ast.Keyword{{Type: ast.KGroup}, {Type: ast.KBy}},
+				Items: []ast.Expression{
+					&ast.ExprCompoundIdentifier{
+						Identifiers: []ast.Expression{
+							&ast.ExprIdentifier{Value: "f"},
+							&ast.ExprIdentifier{Value: "Symbol"},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select f.Symbol from dbo.fn_Trades(1) f group by f.Symbol"
+
+	test(t, expected, input)
+}
 
-	if len(query.Statements) != 1 {
-		t.Fatalf("expected 1 statement, got %d\n %s", len(query.Statements), strings.Join(p.Errors(), "\n"))
+func TestParseConversionFunctions(t *testing.T) {
+	varcharLength := uint32(10)
+	scale := uint32(2)
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprConvert{
+						ConvertKeyword: ast.Keyword{Type: ast.KConvert},
+						DataType:       ast.DataType{Kind: ast.DTVarchar, VarcharLength: &varcharLength},
+						Expression:     &ast.ExprIdentifier{Value: "TradeDate"},
+						Style:          &ast.ExprNumberLiteral{Value: "120"},
+					},
+					&ast.ExprConvert{
+						ConvertKeyword: ast.Keyword{Type: ast.KTryConvert},
+						DataType:       ast.DataType{Kind: ast.DTInt},
+						Expression:     &ast.ExprIdentifier{Value: "Quantity"},
+					},
+					&ast.ExprCast{
+						CastKeyword: ast.Keyword{Type: ast.KTryCast},
+						Expression:  &ast.ExprIdentifier{Value: "Price"},
+						AsKeyword:   ast.Keyword{Type: ast.KAs},
+						DataType: ast.DataType{
+							Kind:               ast.DTDecimal,
+							DecimalNumericSize: &ast.NumericSize{Precision: 10, Scale: &scale},
+						},
+					},
+					&ast.ExprParse{
+						ParseKeyword: ast.Keyword{Type: ast.KParse},
+						Expression:   &ast.ExprIdentifier{Value: "SettleDate"},
+						AsKeyword:    ast.Keyword{Type: ast.KAs},
+						DataType:     ast.DataType{Kind: ast.DTDatetime},
+						UsingKeyword: &ast.Keyword{Type: ast.KUsing},
+						Culture:      &ast.ExprStringLiteral{Value: "en-US"},
+					},
+					&ast.ExprParse{
+						ParseKeyword: ast.Keyword{Type: ast.KTryParse},
+						Expression:   &ast.ExprIdentifier{Value: "Notional"},
+						AsKeyword:    ast.Keyword{Type: ast.KAs},
+						DataType:     ast.DataType{Kind: ast.DTInt},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "Trades"},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select convert(varchar(10), TradeDate, 120), try_convert(int, Quantity), try_cast(Price as decimal(10, 2)),"
+	input += " parse(SettleDate as datetime using 'en-US'), try_parse(Notional as int) from Trades"
+
+	test(t, expected, input)
+}
+
+func TestParseOrderByClause(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "Stock"},
+					&ast.ExprIdentifier{Value: "PercentChange"},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "MarketData"},
+				},
+			},
+			OrderByClause: &ast.OrderByClause{
+				OrderByKeyword: [2]ast.Keyword{
+					{Type: ast.KOrder},
+					{Type: ast.KBy},
+				},
+				Expressions: []ast.OrderByArg{
+					{
+						Column:       &ast.ExprIdentifier{Value: "InsertDate"},
+						Type:         ast.OBDesc,
+						OrderKeyword: &ast.Keyword{Type: ast.KDesc},
+					},
+					{
+						Column:       &ast.ExprIdentifier{Value: "InsertTime"},
+						Type:         ast.OBAsc,
+						OrderKeyword: &ast.Keyword{Type: ast.KAsc},
+					},
+					{
+						Column: &ast.ExprIdentifier{Value: "Stock"},
+					},
+				},
+				OffsetFetch: &ast.OffsetFetchClause{
+					Offset: ast.OffsetArg{
+						OffsetKeyword:    ast.Keyword{Type: ast.KOffset},
+						Value:            &ast.ExprNumberLiteral{Value: "4"},
+						RowOrRows:        ast.RRRow,
+						RowOrRowsKeyword: ast.Keyword{Type: ast.KRow},
+					},
+					Fetch: &ast.FetchArg{
+						FetchKeyword:       ast.Keyword{Type: ast.KFetch},
+						Value:              &ast.ExprNumberLiteral{Value: "20"},
+						NextOrFirst:        ast.NFFirst,
+						RowOrRows:          ast.RRRows,
+						NextOrFirstKeyword: ast.Keyword{Type: ast.KFirst},
+						RowOrRowsKeyword:   ast.Keyword{Type: ast.KRows},
+						OnlyKeyword:        ast.Keyword{Type: ast.KOnly},
+					},
+				},
+			},
+		}}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select Stock, PercentChange FROM MarketData order by InsertDate Desc, InsertTime asc"
+	input += ", Stock offset 4 row fetch first 20 rows only"
+
+	test(t, expected, input)
+}
+
+func TestParseSubqueryCall(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "hello"},
+					&ast.ExprWithAlias{
+						Expression: &ast.ExprSubquery{
+							Query: &ast.SelectBody{
+								SelectKeyword: ast.Keyword{Type: ast.KSelect},
+								Top: &ast.TopArg{
+									TopKeyword:     ast.Keyword{Type: ast.KTop},
+									PercentKeyword: &ast.Keyword{Type: ast.KPercent},
+									Quantity:       &ast.ExprNumberLiteral{Value: "20"},
+								},
+								SelectItems: ast.SelectItems{
+									Items: []ast.Expression{
+										&ast.ExprIdentifier{Value: "yesirr"},
+									},
+								},
+								Table: &ast.TableArg{
+									FromKeyword: ast.Keyword{Type: ast.KFrom},
+									Table: &ast.TableSource{
+										Type:   ast.TSTTable,
+										Source: &ast.ExprIdentifier{Value: "bruh"},
+									},
+								},
+								WhereClause: &ast.WhereClause{
+									WhereKeyword: ast.Keyword{Type: ast.KWhere},
+									Clause: &ast.ExprComparisonOperator{
+										Left: &ast.ExprIdentifier{
+											Value: "LastPrice",
+										},
+										Operator: ast.ComparisonOpLess,
+										Right:    &ast.ExprNumberLiteral{Value: "10.0"}},
+								},
+								OrderByClause: &ast.OrderByClause{
+									OrderByKeyword: [2]ast.Keyword{
+										{Type: ast.KOrder},
+										{Type: ast.KBy},
+									},
+									Expressions: []ast.OrderByArg{
+										{
+											Column:       &ast.ExprIdentifier{Value: "LastPrice"},
+											OrderKeyword: &ast.Keyword{Type: ast.KDesc},
+											Type:         ast.OBDesc,
+										},
+									},
+								},
+							},
+						},
+						Alias: &ast.ExprIdentifier{Value: "NetScore"},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "testtable"},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select hello,  (select  top 20 percent yesirr from bruh where LastPrice < 10.0"
+	input += " order by LastPrice desc) NetScore FROM testtable"
+
+	test(t, expected, input)
+}
+
+func TestParseSomeLogicalOperators(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "Stock"},
+					&ast.ExprIdentifier{Value: "LastPrice"},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "MarketData"},
+				},
+			},
+			WhereClause: &ast.WhereClause{
+				WhereKeyword: ast.Keyword{Type: ast.KWhere},
+				Clause: &ast.ExprOrLogicalOperator{
+					OrKeyword: ast.Keyword{Type: ast.KOr},
+					Left: &ast.ExprAndLogicalOperator{
+						AndKeyword: ast.Keyword{Type: ast.KAnd},
+						Left: &ast.ExprComparisonOperator{
+							Left: &ast.ExprQuotedIdentifier{
+								Value: "LastPrice",
+							},
+							Operator: ast.ComparisonOpLess,
+							Right:    &ast.ExprNumberLiteral{Value: "10.0"}},
+						Right: &ast.ExprInLogicalOperator{
+							InKeyword:      ast.Keyword{Type: ast.KIn},
+							TestExpression: &ast.ExprIdentifier{Value: "Stock"},
+							NotKeyword:     &ast.Keyword{Type: ast.KNot},
+							Expressions: []ast.Expression{
+								&ast.ExprStringLiteral{Value: "AAL"},
+								&ast.ExprStringLiteral{Value: "AMZN"},
+								&ast.ExprStringLiteral{Value: "GOOGL"},
+								&ast.ExprStringLiteral{Value: "ZM"},
+							},
+						},
+					},
+					Right: &ast.ExprBetweenLogicalOperator{
+						BetweenKeyword: ast.Keyword{Type: ast.KBetween},
+						TestExpression: &ast.ExprIdentifier{Value: "PercentChange"},
+						Begin:          &ast.ExprNumberLiteral{Value: "1"},
+						AndKeyword:     ast.Keyword{Type: ast.KAnd},
+						End:            &ast.ExprNumberLiteral{Value: "4"},
+					},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select Stock,  LastPrice FROM MarketData"
+	input += " where [LastPrice] < 10.0 and Stock nOT in ('AAL', 'AMZN', 'GOOGL', 'ZM')"
+	input += "\n or PercentChange Between 1 and 4"
+
+	test(t, expected, input)
+}
+
+func TestParseSelectItemWithAlias(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "hello"},
+					&ast.ExprWithAlias{
+						Expression: &ast.ExprIdentifier{Value: "potate"},
+						Alias:      &ast.ExprStringLiteral{Value: "Potate"},
+					},
+					&ast.ExprSubquery{
+						Query: &ast.SelectBody{
+							SelectKeyword: ast.Keyword{Type: ast.KSelect},
+							SelectItems: ast.SelectItems{
+								Items: []ast.Expression{
+									&ast.ExprWithAlias{
+										Expression: &ast.ExprIdentifier{Value: "dt"},
+										AsKeyword:  &ast.Keyword{Type: ast.KAs},
+										Alias:      &ast.ExprQuotedIdentifier{Value: "Datetime"},
+									},
+								},
+							},
+							Table: &ast.TableArg{
+								FromKeyword: ast.Keyword{Type: ast.KFrom},
+								Table: &ast.TableSource{
+									Type:   ast.TSTTable,
+									Source: &ast.ExprIdentifier{Value: "bruh"},
+								},
+							},
+						},
+					}},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "testtable"},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select hello, potate 'Potate', (select dt as [Datetime] from bruh) FROM testtable"
+
+	test(t, expected, input)
+}
+
+func TestDistinctTopArg(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword:   ast.Keyword{Type: ast.KSelect},
+			DistinctKeyword: &ast.Keyword{Type: ast.KDistinct},
+			Top: &ast.TopArg{
+				TopKeyword:     ast.Keyword{Type: ast.KTop},
+				PercentKeyword: &ast.Keyword{Type: ast.KPercent},
+				Quantity: &ast.ExprNumberLiteral{
+					Value: "44",
+				},
+			},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "hello"},
+					&ast.ExprWithAlias{
+						Expression: &ast.ExprIdentifier{Value: "potate"},
+						Alias:      &ast.ExprIdentifier{Value: "Potate"},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "testtable"},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select distinct top 44 percent hello, potate Potate FROM testtable -- hello lmao"
+
+	test(t, expected, input)
+}
+
+func TestParseCaseExpression(t *testing.T) {
+	select_statement := ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprWithAlias{
+						Expression: &ast.ExprCase{
+							CaseKeyword: ast.Keyword{Type: ast.KCase},
+							WhenClauses: []ast.WhenClause{
+								{
+									WhenKeyword: ast.Keyword{Type: ast.KWhen},
+									Condition: &ast.ExprComparisonOperator{
+										Left:     &ast.ExprIdentifier{Value: "LastPrice"},
+										Operator: ast.ComparisonOpLess,
+										Right:    &ast.ExprNumberLiteral{Value: "10"},
+									},
+									ThenKeyword: ast.Keyword{Type: ast.KThen},
+									Result:      &ast.ExprStringLiteral{Value: "cheap"},
+								},
+							},
+							ElseClause: &ast.ElseClause{
+								ElseKeyword: ast.Keyword{Type: ast.KElse},
+								Result:      &ast.ExprStringLiteral{Value: "expensive"},
+							},
+							EndKeyword: ast.Keyword{Type: ast.KEnd},
+						},
+						AsKeyword: &ast.Keyword{Type: ast.KAs},
+						Alias:     &ast.ExprIdentifier{Value: "PriceRange"},
+					},
+					&ast.ExprFunctionCall{
+						Name: &ast.ExprFunction{
+							Type: ast.FuncSum,
+							Name: &ast.ExprIdentifier{Value: "sum"},
+						},
+						Args: []ast.Expression{
+							&ast.ExprCase{
+								CaseKeyword:     ast.Keyword{Type: ast.KCase},
+								InputExpression: &ast.ExprIdentifier{Value: "Stock"},
+								WhenClauses: []ast.WhenClause{
+									{
+										WhenKeyword: ast.Keyword{Type: ast.KWhen},
+										Condition:   &ast.ExprStringLiteral{Value: "AAL"},
+										ThenKeyword: ast.Keyword{Type: ast.KThen},
+										Result:      &ast.ExprNumberLiteral{Value: "1"},
+									},
+									{
+										WhenKeyword: ast.Keyword{Type: ast.KWhen},
+										Condition:   &ast.ExprStringLiteral{Value: "AMZN"},
+										ThenKeyword: ast.Keyword{Type: ast.KThen},
+										Result:      &ast.ExprNumberLiteral{Value: "2"},
+									},
+								},
+								EndKeyword: ast.Keyword{Type: ast.KEnd},
+							},
+						},
+					},
+				},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "MarketData"},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "select case when LastPrice < 10 then 'cheap' else 'expensive' end as PriceRange,"
+	input += " sum(case Stock when 'AAL' then 1 when 'AMZN' then 2 end) from MarketData"
+
+	test(t, expected, input)
+
+	select_statement = ast.SelectStatement{
+		Query: &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{&ast.ExprIdentifier{Value: "Stock"}},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: "MarketData"},
+				},
+			},
+			OrderByClause: &ast.OrderByClause{
+				OrderByKeyword: [2]ast.Keyword{
+					{Type: ast.KOrder},
+					{Type: ast.KBy},
+				},
+				Expressions: []ast.OrderByArg{
+					{
+						Column: &ast.ExprCase{
+							CaseKeyword:     ast.Keyword{Type: ast.KCase},
+							InputExpression: &ast.ExprIdentifier{Value: "Stock"},
+							WhenClauses: []ast.WhenClause{
+								{
+									WhenKeyword: ast.Keyword{Type: ast.KWhen},
+									Condition:   &ast.ExprStringLiteral{Value: "AAL"},
+									ThenKeyword: ast.Keyword{Type: ast.KThen},
+									Result:      &ast.ExprNumberLiteral{Value: "0"},
+								},
+							},
+							ElseClause: &ast.ElseClause{
+								ElseKeyword: ast.Keyword{Type: ast.KElse},
+								Result:      &ast.ExprNumberLiteral{Value: "1"},
+							},
+							EndKeyword: ast.Keyword{Type: ast.KEnd},
+						},
+					},
+					{
+						Column:       &ast.ExprIdentifier{Value: "Stock"},
+						Type:         ast.OBDesc,
+						OrderKeyword: &ast.Keyword{Type: ast.KDesc},
+					},
+				},
+			},
+		},
+	}
+	expected = ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input = "select Stock from MarketData order by case Stock when 'AAL' then 0 else 1 end, Stock desc"
+
+	test(t, expected, input)
+}
+
+func TestParseSetOperation(t *testing.T) {
+	selectBody := func(column, table string) *ast.SelectBody {
+		return &ast.SelectBody{
+			SelectKeyword: ast.Keyword{Type: ast.KSelect},
+			SelectItems: ast.SelectItems{
+				Items: []ast.Expression{&ast.ExprIdentifier{Value: column}},
+			},
+			Table: &ast.TableArg{
+				FromKeyword: ast.Keyword{Type: ast.KFrom},
+				Table: &ast.TableSource{
+					Type:   ast.TSTTable,
+					Source: &ast.ExprIdentifier{Value: table},
+				},
+			},
+		}
+	}
+	select_statement := ast.SelectStatement{
+		Query: &ast.SetOperation{
+			Left: &ast.ParenthesizedQuery{
+				Query: &ast.SetOperation{
+					Left:             selectBody("a", "t"),
+					OperatorKeywords: []ast.Keyword{{Type: ast.KExcept}},
+					Operator:         ast.SOExcept,
+					Right:            selectBody("a", "u"),
+				},
+			},
+			OperatorKeywords: []ast.Keyword{{Type: ast.KUnion}, {Type: ast.KAll}},
+			Operator:         ast.SOUnionAll,
+			Right: &ast.SetOperation{
+				Left:             selectBody("a", "v"),
+				OperatorKeywords: []ast.Keyword{{Type: ast.KIntersect}},
+				Operator:         ast.SOIntersect,
+				Right:            selectBody("a", "w"),
+			},
+			OrderByClause: &ast.OrderByClause{
+				OrderByKeyword: [2]ast.Keyword{{Type: ast.KOrder}, {Type: ast.KBy}},
+				Expressions: []ast.OrderByArg{
+					{Column: &ast.ExprIdentifier{Value: "a"}},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	input := "(select a from t except select a from u) union all"
+	input += " select a from v intersect select a from w order by a"
+
+	test(t, expected, input)
+
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+	setOperation := query.Batches[0].Statements[0].(*ast.SelectStatement).Query.(*ast.SetOperation)
+	if right, ok := setOperation.Right.(*ast.SetOperation); !ok || right.Operator != ast.SOIntersect {
+		t.Fatalf("expected INTERSECT to bind tighter than UNION ALL, got %s", setOperation.TokenLiteral())
+	}
+
+	select_statement = ast.SelectStatement{
+		Query: &ast.ParenthesizedQuery{
+			Query: selectBody("a", "t"),
+			OrderByClause: &ast.OrderByClause{
+				OrderByKeyword: [2]ast.Keyword{{Type: ast.KOrder}, {Type: ast.KBy}},
+				Expressions: []ast.OrderByArg{
+					{Column: &ast.ExprIdentifier{Value: "a"}},
+				},
+			},
+		},
+	}
+	expected = ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&select_statement}}}}
+
+	test(t, expected, "(select a from t) order by a")
+}
+
+func TestParseInsertStatement(t *testing.T) {
+	insert_statement := ast.InsertStatement{
+		InsertKeyword: ast.Keyword{Type: ast.KInsert},
+		Top: &ast.TopArg{
+			TopKeyword:    ast.Keyword{Type: ast.KTop},
+			Quantity:      &ast.ExprNumberLiteral{Value: "10"},
+			Parenthesized: true,
+		},
+		IntoKeyword: &ast.Keyword{Type: ast.KInto},
+		Target: &ast.ExprCompoundIdentifier{
+			Identifiers: []ast.Expression{
+				&ast.ExprIdentifier{Value: "dbo"},
+				&ast.ExprIdentifier{Value: "Trades"},
+			},
+		},
+		Columns: &ast.ExprExpressionList{
+			List: []ast.Expression{
+				&ast.ExprIdentifier{Value: "Symbol"},
+				&ast.ExprIdentifier{Value: "LastPrice"},
+			},
+		},
+		Values: &ast.TableValueConstructor{
+			ValuesKeyword: ast.Keyword{Type: ast.KValues},
+			Rows: []ast.ExprExpressionList{
+				{
+					List: []ast.Expression{
+						&ast.ExprStringLiteral{Value: "AAL"},
+						&ast.ExprNumberLiteral{Value: "12.5"},
+					},
+				},
+				{
+					List: []ast.Expression{
+						&ast.ExprStringLiteral{Value: "AMZN"},
+						&ast.ExprNullLiteral{},
+					},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&insert_statement}}}}
+
+	input := "insert top (10) into dbo.Trades (Symbol, LastPrice) values ('AAL', 12.5), ('AMZN', null)"
+
+	test(t, expected, input)
+}
+
+func TestParseUpdateStatement(t *testing.T) {
+	update_statement := ast.UpdateStatement{
+		UpdateKeyword: ast.Keyword{Type: ast.KUpdate},
+		Target:        &ast.ExprIdentifier{Value: "mkt"},
+		SetKeyword:    ast.Keyword{Type: ast.KSet},
+		Assignments: []*ast.ExprAssignmentOperator{
+			{
+				Left:     &ast.ExprIdentifier{Value: "LastPrice"},
+				Operator: ast.AssignmentOpEqual,
+				Right:    &ast.ExprNumberLiteral{Value: "10"},
+			},
+			{
+				Left:     &ast.ExprIdentifier{Value: "Volume"},
+				Operator: ast.AssignmentOpPlusEqual,
+				Right:    &ast.ExprNumberLiteral{Value: "5"},
+			},
+			{
+				Left:     &ast.ExprLocalVariable{Value: "Total"},
+				Operator: ast.AssignmentOpEqual,
+				Right: &ast.ExprAssignmentOperator{
+					Left:     &ast.ExprIdentifier{Value: "Total"},
+					Operator: ast.AssignmentOpEqual,
+					Right: &ast.ExprArithmeticOperator{
+						Left:     &ast.ExprIdentifier{Value: "Total"},
+						Operator: ast.ArithmeticOpPlus,
+						Right:    &ast.ExprNumberLiteral{Value: "1"},
+					},
+				},
+			},
+		},
+		Table: &ast.TableArg{
+			FromKeyword: ast.Keyword{Type: ast.KFrom},
+			Table: &ast.TableSource{
+				Type: ast.TSTTable,
+				Source: &ast.ExprWithAlias{
+					Expression: &ast.ExprIdentifier{Value: "MarketData"},
+					Alias:      &ast.ExprIdentifier{Value: "mkt"},
+				},
+			},
+		},
+		WhereClause: &ast.WhereClause{
+			WhereKeyword: ast.Keyword{Type: ast.KWhere},
+			Clause: &ast.ExprComparisonOperator{
+				Left:     &ast.ExprIdentifier{Value: "Symbol"},
+				Operator: ast.ComparisonOpEqual,
+				Right:    &ast.ExprStringLiteral{Value: "AAL"},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&update_statement}}}}
+
+	input := "update mkt set LastPrice = 10, Volume += 5, @Total = Total = Total + 1"
+	input += " from MarketData mkt where Symbol = 'AAL'"
+
+	test(t, expected, input)
+}
+
+func TestParseDeleteStatement(t *testing.T) {
+	delete_statement := ast.DeleteStatement{
+		DeleteKeyword: ast.Keyword{Type: ast.KDelete},
+		Top: &ast.TopArg{
+			TopKeyword:    ast.Keyword{Type: ast.KTop},
+			Quantity:      &ast.ExprNumberLiteral{Value: "500"},
+			Parenthesized: true,
+		},
+		FromKeyword: &ast.Keyword{Type: ast.KFrom},
+		Target:      &ast.ExprIdentifier{Value: "mkt"},
+		Table: &ast.TableArg{
+			FromKeyword: ast.Keyword{Type: ast.KFrom},
+			Table: &ast.TableSource{
+				Type: ast.TSTTable,
+				Source: &ast.ExprWithAlias{
+					Expression: &ast.ExprIdentifier{Value: "MarketData"},
+					Alias:      &ast.ExprIdentifier{Value: "mkt"},
+				},
+			},
+			Joins: []ast.Join{
+				{
+					JoinTypeKeyword: []ast.Keyword{{Type: ast.KInner}, {Type: ast.KJoin}},
+					Type:            ast.JTInner,
+					Table: &ast.TableSource{
+						Type: ast.TSTTable,
+						Source: &ast.ExprWithAlias{
+							Expression: &ast.ExprIdentifier{Value: "Delisted"},
+							Alias:      &ast.ExprIdentifier{Value: "d"},
+						},
+					},
+					OnKeyword: &ast.Keyword{Type: ast.KOn},
+					Condition: &ast.ExprComparisonOperator{
+						Left: &ast.ExprCompoundIdentifier{
+							Identifiers: []ast.Expression{
+								&ast.ExprIdentifier{Value: "d"},
+								&ast.ExprIdentifier{Value: "Symbol"},
+							},
+						},
+						Operator: ast.ComparisonOpEqual,
+						Right: &ast.ExprCompoundIdentifier{
+							Identifiers: []ast.Expression{
+								&ast.ExprIdentifier{Value: "mkt"},
+								&ast.ExprIdentifier{Value: "Symbol"},
+							},
+						},
+					},
+				},
+			},
+		},
+		WhereClause: &ast.WhereClause{
+			WhereKeyword: ast.Keyword{Type: ast.KWhere},
+			Clause: &ast.ExprComparisonOperator{
+				Left:     &ast.ExprIdentifier{Value: "LastPrice"},
+				Operator: ast.ComparisonOpLess,
+				Right:    &ast.ExprNumberLiteral{Value: "1"},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&delete_statement}}}}
+
+	input := "delete top (500) from mkt from MarketData mkt inner join Delisted d on d.Symbol = mkt.Symbol"
+	input += " where LastPrice < 1;"
+
+	test(t, expected, input)
+}
+
+func TestParseOutputClause(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	input := "insert into Quotes (Symbol) output inserted.Id, inserted.Symbol into @Audit (Id, Symbol) values ('AAL');"
+	input += " update Quotes set Price = 11 output deleted.Price, q.Price where Symbol = 'AAL';"
+	input += " delete from Quotes output deleted.*"
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("expected no errors, got %s", strings.Join(p.Errors(), "\n"))
+	}
+	statements := query.Batches[0].Statements
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(statements))
+	}
+
+	insert := statements[0].(*ast.InsertStatement)
+	if insert.OutputClause == nil || insert.OutputClause.IntoColumns == nil || insert.Values == nil {
+		t.Fatalf("expected insert to output into @Audit and keep its values, got %s", insert.TokenLiteral())
+	}
+	update := statements[1].(*ast.UpdateStatement)
+	if update.OutputClause == nil || update.WhereClause == nil {
+		t.Fatalf("expected update to have an output and a where clause, got %s", update.TokenLiteral())
+	}
+	del := statements[2].(*ast.DeleteStatement)
+	if del.OutputClause == nil {
+		t.Fatalf("expected delete to have an output clause, got %s", del.TokenLiteral())
+	}
+
+	pseudoTables := []ast.PseudoTable{}
+	for _, stmt := range statements {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.ExprCompoundIdentifier); ok {
+				pseudoTables = append(pseudoTables, ident.PseudoTable)
+			}
+			return true
+		})
+	}
+	expected := []ast.PseudoTable{ast.PTInserted, ast.PTInserted, ast.PTDeleted, ast.PTNone, ast.PTDeleted}
+	if len(pseudoTables) != len(expected) {
+		t.Fatalf("expected %d compound identifiers, got %d", len(expected), len(pseudoTables))
+	}
+	for i := range expected {
+		if pseudoTables[i] != expected[i] {
+			t.Fatalf("expected pseudo table %d for identifier %d, got %d", expected[i], i, pseudoTables[i])
+		}
+	}
+}
+
+func TestParseUnknownStatement(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	p := NewParser(logger.Sugar(), lexer.NewLexer("truncate MarketData; delete from MarketData"))
+	query := p.Parse()
+
+	if len(p.Errors()) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(p.Errors()))
+	}
+	if len(query.Batches[0].Statements) != 1 {
+		t.Fatalf("expected 1 statement after the unknown one, got %d", len(query.Batches[0].Statements))
+	}
+}
+
+func TestParseUnparenthesizedTop(t *testing.T) {
+	inputs := []string{
+		"insert top 10 into Quotes values (1)",
+		"update top 10 Quotes set Price = 1",
+		"delete top 10 from Quotes",
+		"merge top 10 into Quotes using Staging s on 1 = 1 when matched then delete;",
+	}
+
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	for _, input := range inputs {
+		p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+		p.Parse()
+
+		// the statement is skipped so the rest of it can report more errors
+		if len(p.Errors()) == 0 {
+			t.Fatalf("expected an error for %s", input)
+		}
+		if !strings.Contains(p.Errors()[0], "expected (()") || !strings.Contains(p.Errors()[0], "^") {
+			t.Fatalf("expected the error to point at the TOP expression, got %s", p.Errors()[0])
+		}
+	}
+}
+
+func TestParseSubquerySetOperations(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	input := "select a from t where a in (select a from u union select a from v) and exists (select 1 from w except select 2 from x)"
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected errors %v", p.Errors())
+	}
+	body := query.Batches[0].Statements[0].(*ast.SelectStatement).Query.(*ast.SelectBody)
+	and := body.WhereClause.Clause.(*ast.ExprAndLogicalOperator)
+	if _, ok := and.Left.(*ast.ExprInSubqueryLogicalOperator).Subquery.Query.(*ast.SetOperation); !ok {
+		t.Fatalf("expected the IN subquery to be a set operation")
+	}
+	if _, ok := and.Right.(*ast.ExprExistsLogicalOperator).Subquery.Query.(*ast.SetOperation); !ok {
+		t.Fatalf("expected the EXISTS subquery to be a set operation")
+	}
+
+	input = "select d.a from (select a from u union all select a from v) d"
+	p = NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query = p.Parse()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("unexpected errors %v", p.Errors())
+	}
+	body = query.Batches[0].Statements[0].(*ast.SelectStatement).Query.(*ast.SelectBody)
+	source, ok := body.Table.Table.Source.(*ast.ExprWithAlias).Expression.(*ast.ExprSubquery)
+	if !ok {
+		t.Fatalf("expected a derived table")
+	}
+	if _, ok := source.Query.(*ast.SetOperation); !ok {
+		t.Fatalf("expected the derived table to be a set operation")
+	}
+}
+
+func TestParseBatches(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	input := "create proc LoadQuotes as select 1\nGO\nselect from\ngo 5\nselect , Symbol\nGO\nselect go from Quotes"
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+
+	if len(p.Errors()) != 2 {
+		t.Fatalf("expected an error from each of the broken batches, got %d", len(p.Errors()))
+	}
+	if len(query.Batches) != 4 {
+		t.Fatalf("expected 4 batches, got %d", len(query.Batches))
+	}
+	procedure := query.Batches[0].Statements[0].(*ast.CreateProcedureStatement)
+	if len(procedure.Statements) != 1 {
+		t.Fatalf("expected the procedure body to stop at GO, got %d statements", len(procedure.Statements))
+	}
+	if query.Batches[1].Count == nil || query.Batches[1].Count.Value != "5" {
+		t.Fatalf("expected the second batch to repeat 5 times")
+	}
+	last := query.Batches[3]
+	if last.GoKeyword != nil || len(last.Statements) != 1 {
+		t.Fatalf("expected the last batch to have 1 statement and no GO")
+	}
+}
+
+func TestParseMergeStatement(t *testing.T) {
+	symbolMatch := &ast.ExprComparisonOperator{
+		Left: &ast.ExprCompoundIdentifier{
+			Identifiers: []ast.Expression{
+				&ast.ExprIdentifier{Value: "t"},
+				&ast.ExprIdentifier{Value: "Symbol"},
+			},
+		},
+		Operator: ast.ComparisonOpEqual,
+		Right: &ast.ExprCompoundIdentifier{
+			Identifiers: []ast.Expression{
+				&ast.ExprIdentifier{Value: "s"},
+				&ast.ExprIdentifier{Value: "Symbol"},
+			},
+		},
+	}
+	sourcePrice := &ast.ExprCompoundIdentifier{
+		Identifiers: []ast.Expression{
+			&ast.ExprIdentifier{Value: "s"},
+			&ast.ExprIdentifier{Value: "Price"},
+		},
+	}
+	merge_statement := ast.MergeStatement{
+		MergeKeyword: ast.Keyword{Type: ast.KMerge},
+		IntoKeyword:  &ast.Keyword{Type: ast.KInto},
+		Target: &ast.ExprWithAlias{
+			Expression: &ast.ExprIdentifier{Value: "MarketData"},
+			AsKeyword:  &ast.Keyword{Type: ast.KAs},
+			Alias:      &ast.ExprIdentifier{Value: "t"},
+		},
+		UsingKeyword: ast.Keyword{Type: ast.KUsing},
+		Source: &ast.TableSource{
+			Type: ast.TSTTable,
+			Source: &ast.ExprWithAlias{
+				Expression: &ast.ExprIdentifier{Value: "Quotes"},
+				Alias:      &ast.ExprIdentifier{Value: "s"},
+			},
+		},
+		OnKeyword: ast.Keyword{Type: ast.KOn},
+		Condition: symbolMatch,
+		WhenClauses: []ast.MergeWhenClause{
+			{
+				WhenKeywords: []ast.Keyword{{Type: ast.KWhen}, {Type: ast.KMatched}},
+				Type:         ast.MWMatched,
+				ThenKeyword:  ast.Keyword{Type: ast.KThen},
+				Action: &ast.MergeUpdateAction{
+					UpdateKeyword: ast.Keyword{Type: ast.KUpdate},
+					SetKeyword:    ast.Keyword{Type: ast.KSet},
+					Assignments: []*ast.ExprAssignmentOperator{
+						{
+							Left:     &ast.ExprIdentifier{Value: "LastPrice"},
+							Operator: ast.AssignmentOpEqual,
+							Right:    sourcePrice,
+						},
+					},
+				},
+			},
+			{
+				WhenKeywords: []ast.Keyword{
+					{Type: ast.KWhen},
+					{Type: ast.KNot},
+					{Type: ast.KMatched},
+					{Type: ast.KBy},
+					{Type: ast.KTarget},
+				},
+				Type:        ast.MWNotMatchedByTarget,
+				ThenKeyword: ast.Keyword{Type: ast.KThen},
+				Action: &ast.MergeInsertAction{
+					InsertKeyword: ast.Keyword{Type: ast.KInsert},
+					Columns: &ast.ExprExpressionList{
+						List: []ast.Expression{
+							&ast.ExprIdentifier{Value: "Symbol"},
+							&ast.ExprIdentifier{Value: "LastPrice"},
+						},
+					},
+					Values: &ast.TableValueConstructor{
+						ValuesKeyword: ast.Keyword{Type: ast.KValues},
+						Rows: []ast.ExprExpressionList{
+							{
+								List: []ast.Expression{
+									&ast.ExprCompoundIdentifier{
+										Identifiers: []ast.Expression{
+											&ast.ExprIdentifier{Value: "s"},
+											&ast.ExprIdentifier{Value: "Symbol"},
+										},
+									},
+									sourcePrice,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				WhenKeywords: []ast.Keyword{
+					{Type: ast.KWhen},
+					{Type: ast.KNot},
+					{Type: ast.KMatched},
+					{Type: ast.KBy},
+					{Type: ast.KSource},
+				},
+				Type:        ast.MWNotMatchedBySource,
+				ThenKeyword: ast.Keyword{Type: ast.KThen},
+				Action:      &ast.MergeDeleteAction{DeleteKeyword: ast.Keyword{Type: ast.KDelete}},
+			},
+		},
+		OutputClause: &ast.OutputClause{
+			OutputKeyword: ast.Keyword{Type: ast.KOutput},
+			Columns: ast.SelectItems{
+				Items: []ast.Expression{
+					&ast.ExprIdentifier{Value: "$action"},
+					&ast.ExprCompoundIdentifier{
+						Identifiers: []ast.Expression{
+							&ast.ExprIdentifier{Value: "inserted"},
+							&ast.ExprStar{},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&merge_statement}}}}
+
+	input := "merge into MarketData as t using Quotes s on t.Symbol = s.Symbol"
+	input += " when matched then update set LastPrice = s.Price"
+	input += " when not matched by target then insert (Symbol, LastPrice) values (s.Symbol, s.Price)"
+	input += " when not matched by source then delete"
+	input += " output $action, inserted.*;"
+
+	test(t, expected, input)
+
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	p := NewParser(logger.Sugar(), lexer.NewLexer(input))
+	query := p.Parse()
+	stmt := query.Batches[0].Statements[0].(*ast.MergeStatement)
+	for i, w := range stmt.WhenClauses {
+		if w.Type != merge_statement.WhenClauses[i].Type {
+			t.Fatalf("expected when clause %d to have type %d, got %d", i, merge_statement.WhenClauses[i].Type, w.Type)
+		}
+	}
+
+	p = NewParser(logger.Sugar(), lexer.NewLexer("merge MarketData using Quotes s on 1 = 1 when matched then insert values (1)"))
+	p.Parse()
+	if len(p.Errors()) != 1 {
+		t.Fatalf("expected an error for an insert in a matched arm, got %d errors", len(p.Errors()))
+	}
+}
+
+func TestParseDeclareStatement(t *testing.T) {
+	length := uint32(10)
+	declare_statement := ast.DeclareStatement{
+		DeclareKeyword: ast.Keyword{Type: ast.KDeclare},
+		Variables: []ast.VariableDeclaration{
+			{
+				Name:     ast.ExprLocalVariable{Value: "Count"},
+				DataType: &ast.DataType{Kind: ast.DTInt},
+				Value:    &ast.ExprNumberLiteral{Value: "1"},
+			},
+			{
+				Name:     ast.ExprLocalVariable{Value: "Symbol"},
+				DataType: &ast.DataType{Kind: ast.DTVarchar, VarcharLength: &length},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&declare_statement}}}}
+
+	input := "declare @Count int = 1, @Symbol varchar(10);"
+
+	test(t, expected, input)
+}
+
+func TestParseSetLocalVariableStatement(t *testing.T) {
+	set_statement := ast.SetLocalVariableStatement{
+		SetKeyword: ast.Keyword{Type: ast.KSet},
+		Assignment: &ast.ExprAssignmentOperator{
+			Left:     &ast.ExprLocalVariable{Value: "Count"},
+			Operator: ast.AssignmentOpPlusEqual,
+			Right: &ast.ExprArithmeticOperator{
+				Left:     &ast.ExprLocalVariable{Value: "Step"},
+				Operator: ast.ArithmeticOpMult,
+				Right:    &ast.ExprNumberLiteral{Value: "2"},
+			},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&set_statement}}}}
+
+	input := "set @Count += @Step * 2"
+
+	test(t, expected, input)
+}
+
+func TestParseControlOfFlowStatements(t *testing.T) {
+	counter := &ast.ExprLocalVariable{Value: "i"}
+	while_statement := ast.WhileStatement{
+		WhileKeyword: ast.Keyword{Type: ast.KWhile},
+		Condition: &ast.ExprComparisonOperator{
+			Left:     counter,
+			Operator: ast.ComparisonOpLess,
+			Right:    &ast.ExprNumberLiteral{Value: "10"},
+		},
+		Body: &ast.BlockStatement{
+			BeginKeyword: ast.Keyword{Type: ast.KBegin},
+			Statements: []ast.Statement{
+				&ast.SetLocalVariableStatement{
+					SetKeyword: ast.Keyword{Type: ast.KSet},
+					Assignment: &ast.ExprAssignmentOperator{
+						Left:     counter,
+						Operator: ast.AssignmentOpPlusEqual,
+						Right:    &ast.ExprNumberLiteral{Value: "1"},
+					},
+				},
+				&ast.IfStatement{
+					IfKeyword: ast.Keyword{Type: ast.KIf},
+					Condition: &ast.ExprComparisonOperator{
+						Left:     counter,
+						Operator: ast.ComparisonOpEqual,
+						Right:    &ast.ExprNumberLiteral{Value: "5"},
+					},
+					Then:        &ast.ContinueStatement{ContinueKeyword: ast.Keyword{Type: ast.KContinue}},
+					ElseKeyword: &ast.Keyword{Type: ast.KElse},
+					Else: &ast.IfStatement{
+						IfKeyword: ast.Keyword{Type: ast.KIf},
+						Condition: &ast.ExprComparisonOperator{
+							Left:     counter,
+							Operator: ast.ComparisonOpGreater,
+							Right:    &ast.ExprNumberLiteral{Value: "8"},
+						},
+						Then:        &ast.BreakStatement{BreakKeyword: ast.Keyword{Type: ast.KBreak}},
+						ElseKeyword: &ast.Keyword{Type: ast.KElse},
+						Else: &ast.ReturnStatement{
+							ReturnKeyword: ast.Keyword{Type: ast.KReturn},
+							Value:         counter,
+						},
+					},
+				},
+			},
+			EndKeyword: ast.Keyword{Type: ast.KEnd},
+		},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&while_statement}}}}
+
+	input := "while @i < 10 begin set @i += 1; if @i = 5 continue; else if @i > 8 break else return @i end"
+
+	test(t, expected, input)
+}
+
+func TestParseTryCatchStatement(t *testing.T) {
+	try_catch_statement := ast.TryCatchStatement{
+		BeginTryKeywords: [2]ast.Keyword{{Type: ast.KBegin}, {Type: ast.KTry}},
+		TryStatements: []ast.Statement{
+			&ast.PrintStatement{
+				PrintKeyword: ast.Keyword{Type: ast.KPrint},
+				Value:        &ast.ExprStringLiteral{Value: "loading"},
+			},
+			&ast.ThrowStatement{
+				ThrowKeyword: ast.Keyword{Type: ast.KThrow},
+				ErrorNumber:  &ast.ExprNumberLiteral{Value: "50000"},
+				Message:      &ast.ExprStringLiteral{Value: "no rows"},
+				State:        &ast.ExprNumberLiteral{Value: "1"},
+			},
+		},
+		EndTryKeywords:     [2]ast.Keyword{{Type: ast.KEnd}, {Type: ast.KTry}},
+		BeginCatchKeywords: [2]ast.Keyword{{Type: ast.KBegin}, {Type: ast.KCatch}},
+		CatchStatements: []ast.Statement{
+			&ast.RaiserrorStatement{
+				RaiserrorKeyword: ast.Keyword{Type: ast.KRaiserror},
+				Arguments: []ast.Expression{
+					&ast.ExprStringLiteral{Value: "load failed"},
+					&ast.ExprNumberLiteral{Value: "16"},
+					&ast.ExprNumberLiteral{Value: "1"},
+				},
+				WithKeyword: &ast.Keyword{Type: ast.KWith},
+				Options:     []ast.Keyword{{Type: ast.KNowait}},
+			},
+			&ast.ThrowStatement{ThrowKeyword: ast.Keyword{Type: ast.KThrow}},
+		},
+		EndCatchKeywords: [2]ast.Keyword{{Type: ast.KEnd}, {Type: ast.KCatch}},
+	}
+	expected := ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{&try_catch_statement}}}}
+
+	input := "begin try print 'loading'; throw 50000, 'no rows', 1; end try"
+	input += " begin catch raiserror('load failed', 16, 1) with nowait; throw; end catch"
+
+	test(t, expected, input)
+}
+
+func TestParseTransactionStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ast.Statement
+	}{
+		{
+			input: "begin transaction LoadQuotes",
+			expected: &ast.BeginTransactionStatement{
+				BeginKeyword:       ast.Keyword{Type: ast.KBegin},
+				TransactionKeyword: ast.Keyword{Type: ast.KTransaction},
+				Name:               &ast.ExprIdentifier{Value: "LoadQuotes"},
+			},
+		},
+		{
+			input: "save tran BeforeDelete;",
+			expected: &ast.SaveTransactionStatement{
+				SaveKeyword:        ast.Keyword{Type: ast.KSave},
+				TransactionKeyword: ast.Keyword{Type: ast.KTran},
+				Name:               &ast.ExprIdentifier{Value: "BeforeDelete"},
+			},
+		},
+		{
+			input: "rollback tran @Savepoint",
+			expected: &ast.RollbackTransactionStatement{
+				RollbackKeyword:    ast.Keyword{Type: ast.KRollback},
+				TransactionKeyword: &ast.Keyword{Type: ast.KTran},
+				Name:               &ast.ExprLocalVariable{Value: "Savepoint"},
+			},
+		},
+		{
+			input:    "commit",
+			expected: &ast.CommitTransactionStatement{CommitKeyword: ast.Keyword{Type: ast.KCommit}},
+		},
+		{
+			input: "set xact_abort on",
+			expected: &ast.SetOptionStatement{
+				SetKeyword: ast.Keyword{Type: ast.KSet},
+				Options:    []ast.Keyword{{Type: ast.KXactAbort}},
+				Value:      ast.Keyword{Type: ast.KOn},
+			},
+		},
+		{
+			input: "set quoted_identifier, ansi_padding off",
+			expected: &ast.SetOptionStatement{
+				SetKeyword: ast.Keyword{Type: ast.KSet},
+				Options:    []ast.Keyword{{Type: ast.KQuotedIdentifier}, {Type: ast.KAnsiPadding}},
+				Value:      ast.Keyword{Type: ast.KOff},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		test(t, ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{tt.expected}}}}, tt.input)
+	}
+}
+
+func TestParseExecuteStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ast.Statement
+	}{
+		{
+			input: "exec @ret = dbo.LoadQuotes @Symbol = 'AAL', @Rows = @Rows output, default",
+			expected: &ast.ExecuteStatement{
+				ExecKeyword:  ast.Keyword{Type: ast.KExec},
+				ReturnStatus: &ast.ExprLocalVariable{Value: "ret"},
+				Name: &ast.ExprCompoundIdentifier{Identifiers: []ast.Expression{
+					&ast.ExprIdentifier{Value: "dbo"},
+					&ast.ExprIdentifier{Value: "LoadQuotes"},
+				}},
+				Arguments: []ast.ExecuteArgument{
+					{
+						Name:  &ast.ExprLocalVariable{Value: "Symbol"},
+						Value: &ast.ExprStringLiteral{Value: "AAL"},
+					},
+					{
+						Name:          &ast.ExprLocalVariable{Value: "Rows"},
+						Value:         &ast.ExprLocalVariable{Value: "Rows"},
+						OutputKeyword: &ast.Keyword{Type: ast.KOutput},
+					},
+					{DefaultKeyword: &ast.Keyword{Type: ast.KDefault}},
+				},
+			},
+		},
+		{
+			input: "execute (@sql)",
+			expected: &ast.ExecuteStatement{
+				ExecKeyword: ast.Keyword{Type: ast.KExecute},
+				Command:     &ast.ExprLocalVariable{Value: "sql"},
+			},
+		},
+		{
+			input: "exec sp_executesql @stmt, N'@id INT', @id = 5",
+			expected: &ast.ExecuteStatement{
+				ExecKeyword: ast.Keyword{Type: ast.KExec},
+				Name:        &ast.ExprIdentifier{Value: "sp_executesql"},
+				Arguments: []ast.ExecuteArgument{
+					{Value: &ast.ExprLocalVariable{Value: "stmt"}},
+					{Value: &ast.ExprStringLiteral{Value: "@id INT", Unicode: true}},
+					{
+						Name:  &ast.ExprLocalVariable{Value: "id"},
+						Value: &ast.ExprNumberLiteral{Value: "5"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		test(t, ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{tt.expected}}}}, tt.input)
+	}
+}
+
+func TestParseTableStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ast.Statement
+	}{
+		{
+			input: "create table Trades (TradeId int identity(1, 1) primary key, Symbol nvarchar(max) not null default 'AAL'," +
+				" constraint FK_Trades foreign key (Symbol) references Symbols (Symbol) on delete cascade)",
+			expected: &ast.CreateTableStatement{
+				CreateKeyword: ast.Keyword{Type: ast.KCreate},
+				TableKeyword:  ast.Keyword{Type: ast.KTable},
+				Name:          &ast.ExprIdentifier{Value: "Trades"},
+				Definitions: []ast.TableElement{
+					&ast.ColumnDefinition{
+						Name:     &ast.ExprIdentifier{Value: "TradeId"},
+						DataType: &ast.DataType{Kind: ast.DTInt},
+						Identity: &ast.IdentityProperty{
+							IdentityKeyword: ast.Keyword{Type: ast.KIdentity},
+							Seed:            &ast.ExprNumberLiteral{Value: "1"},
+							Increment:       &ast.ExprNumberLiteral{Value: "1"},
+						},
+						Constraints: []ast.TableConstraint{
+							{
+								Type:         ast.CTPrimaryKey,
+								TypeKeywords: []ast.Keyword{{Type: ast.KPrimary}, {Type: ast.KKey}},
+							},
+						},
+					},
+					&ast.ColumnDefinition{
+						Name:         &ast.ExprIdentifier{Value: "Symbol"},
+						DataType:     &ast.DataType{Kind: ast.DTNvarchar, MaxLength: true},
+						NullKeywords: []ast.Keyword{{Type: ast.KNot}, {Type: ast.KNull}},
+						Constraints: []ast.TableConstraint{
+							{
+								Type:         ast.CTDefault,
+								TypeKeywords: []ast.Keyword{{Type: ast.KDefault}},
+								Expression:   &ast.ExprStringLiteral{Value: "AAL"},
+							},
+						},
+					},
+					&ast.TableConstraint{
+						ConstraintKeyword: &ast.Keyword{Type: ast.KConstraint},
+						Name:              &ast.ExprIdentifier{Value: "FK_Trades"},
+						Type:              ast.CTForeignKey,
+						TypeKeywords:      []ast.Keyword{{Type: ast.KForeign}, {Type: ast.KKey}},
+						Columns:           []ast.OrderByArg{{Column: &ast.ExprIdentifier{Value: "Symbol"}}},
+						ReferencesKeyword: &ast.Keyword{Type: ast.KReferences},
+						ReferencedTable:   &ast.ExprIdentifier{Value: "Symbols"},
+						ReferencedColumns: &ast.ExprExpressionList{List: []ast.Expression{
+							&ast.ExprIdentifier{Value: "Symbol"},
+						}},
+						OnDeleteKeywords: []ast.Keyword{{Type: ast.KOn}, {Type: ast.KDelete}, {Type: ast.KCascade}},
+					},
+				},
+			},
+		},
+		{
+			input: "alter table Trades drop constraint FK_Trades",
+			expected: &ast.AlterTableStatement{
+				AlterKeyword: ast.Keyword{Type: ast.KAlter},
+				TableKeyword: ast.Keyword{Type: ast.KTable},
+				Name:         &ast.ExprIdentifier{Value: "Trades"},
+				Action: &ast.AlterTableDropAction{
+					DropKeyword:   ast.Keyword{Type: ast.KDrop},
+					TargetKeyword: &ast.Keyword{Type: ast.KConstraint},
+					Names:         []ast.Expression{&ast.ExprIdentifier{Value: "FK_Trades"}},
+				},
+			},
+		},
+		{
+			input: "drop table if exists Trades",
+			expected: &ast.DropTableStatement{
+				DropKeyword:      ast.Keyword{Type: ast.KDrop},
+				TableKeyword:     ast.Keyword{Type: ast.KTable},
+				IfExistsKeywords: []ast.Keyword{{Type: ast.KIf}, {Type: ast.KExists}},
+				Names:            []ast.Expression{&ast.ExprIdentifier{Value: "Trades"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		test(t, ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{tt.expected}}}}, tt.input)
+	}
+}
+
+func TestParseRoutineDefinitions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ast.Statement
+	}{
+		{
+			input: "create proc dbo.GetQuotes (@Symbol varchar(10) = 'AAL', @Rows int out) with execute as 'loader' as select @Rows = 1",
+			expected: &ast.CreateProcedureStatement{
+				CreateKeywords:   []ast.Keyword{{Type: ast.KCreate}},
+				ProcedureKeyword: ast.Keyword{Type: ast.KProc},
+				Name: &ast.ExprCompoundIdentifier{Identifiers: []ast.Expression{
+					&ast.ExprIdentifier{Value: "dbo"},
+					&ast.ExprIdentifier{Value: "GetQuotes"},
+				}},
+				Parameters: []ast.RoutineParameter{
+					{
+						Name:     ast.ExprLocalVariable{Value: "Symbol"},
+						DataType: &ast.DataType{Kind: ast.DTVarchar, VarcharLength: &[]uint32{10}[0]},
+						Default:  &ast.ExprStringLiteral{Value: "AAL"},
+					},
+					{
+						Name:           ast.ExprLocalVariable{Value: "Rows"},
+						DataType:       &ast.DataType{Kind: ast.DTInt},
+						OptionKeywords: []ast.Keyword{{Type: ast.KOut}},
+					},
+				},
+				WithKeyword: &ast.Keyword{Type: ast.KWith},
+				Options: []ast.RoutineOption{
+					{
+						Keywords: []ast.Keyword{{Type: ast.KExecute}, {Type: ast.KAs}},
+						Value:    &ast.ExprStringLiteral{Value: "loader"},
+					},
+				},
+				AsKeyword: ast.Keyword{Type: ast.KAs},
+				Statements: []ast.Statement{
+					&ast.SelectStatement{Query: &ast.SelectBody{
+						SelectKeyword: ast.Keyword{Type: ast.KSelect},
+						SelectItems: ast.SelectItems{Items: []ast.Expression{
+							&ast.ExprAssignmentOperator{
+								Left:     &ast.ExprLocalVariable{Value: "Rows"},
+								Operator: ast.AssignmentOpEqual,
+								Right:    &ast.ExprNumberLiteral{Value: "1"},
+							},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			input: "create or alter function fn_Symbols() returns table as return select Symbol from Quotes",
+			expected: &ast.CreateFunctionStatement{
+				CreateKeywords:     []ast.Keyword{{Type: ast.KCreate}, {Type: ast.KOr}, {Type: ast.KAlter}},
+				FunctionKeyword:    ast.Keyword{Type: ast.KFunction},
+				Name:               &ast.ExprIdentifier{Value: "fn_Symbols"},
+				ReturnsKeyword:     ast.Keyword{Type: ast.KReturns},
+				Type:               ast.FTInlineTable,
+				ReturnTableKeyword: &ast.Keyword{Type: ast.KTable},
+				AsKeyword:          &ast.Keyword{Type: ast.KAs},
+				ReturnKeyword:      &ast.Keyword{Type: ast.KReturn},
+				ReturnQuery: &ast.SelectBody{
+					SelectKeyword: ast.Keyword{Type: ast.KSelect},
+					SelectItems: ast.SelectItems{Items: []ast.Expression{
+						&ast.ExprIdentifier{Value: "Symbol"},
+					}},
+					Table: &ast.TableArg{
+						FromKeyword: ast.Keyword{Type: ast.KFrom},
+						Table: &ast.TableSource{
+							Type:   ast.TSTTable,
+							Source: &ast.ExprIdentifier{Value: "Quotes"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		test(t, ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{tt.expected}}}}, tt.input)
+	}
+}
+
+func TestParseViewIndexTriggerStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ast.Statement
+	}{
+		{
+			input: "create or alter view ActiveQuotes (Symbol) with schemabinding as select Symbol from Quotes",
+			expected: &ast.CreateViewStatement{
+				CreateKeywords: []ast.Keyword{{Type: ast.KCreate}, {Type: ast.KOr}, {Type: ast.KAlter}},
+				ViewKeyword:    ast.Keyword{Type: ast.KView},
+				Name:           &ast.ExprIdentifier{Value: "ActiveQuotes"},
+				Columns: &ast.ExprExpressionList{List: []ast.Expression{
+					&ast.ExprIdentifier{Value: "Symbol"},
+				}},
+				WithKeyword: &ast.Keyword{Type: ast.KWith},
+				Options: []ast.RoutineOption{
+					{Keywords: []ast.Keyword{{Type: ast.KSchemabinding}}},
+				},
+				AsKeyword: ast.Keyword{Type: ast.KAs},
+				Query: &ast.SelectStatement{Query: &ast.SelectBody{
+					SelectKeyword: ast.Keyword{Type: ast.KSelect},
+					SelectItems: ast.SelectItems{Items: []ast.Expression{
+						&ast.ExprIdentifier{Value: "Symbol"},
+					}},
+					Table: &ast.TableArg{
+						FromKeyword: ast.Keyword{Type: ast.KFrom},
+						Table: &ast.TableSource{
+							Type:   ast.TSTTable,
+							Source: &ast.ExprIdentifier{Value: "Quotes"},
+						},
+					},
+				}},
+			},
+		},
+		{
+			input: "create unique nonclustered index IX_Quotes on Quotes (Symbol desc) include (LastPrice) where LastPrice > 0 with (fillfactor = 80, online = on)",
+			expected: &ast.CreateIndexStatement{
+				CreateKeyword:    ast.Keyword{Type: ast.KCreate},
+				UniqueKeyword:    &ast.Keyword{Type: ast.KUnique},
+				ClusteredKeyword: &ast.Keyword{Type: ast.KNonclustered},
+				IndexKeyword:     ast.Keyword{Type: ast.KIndex},
+				Name:             &ast.ExprIdentifier{Value: "IX_Quotes"},
+				OnKeyword:        ast.Keyword{Type: ast.KOn},
+				Table:            &ast.ExprIdentifier{Value: "Quotes"},
+				Columns: []ast.OrderByArg{
+					{
+						Column:       &ast.ExprIdentifier{Value: "Symbol"},
+						Type:         ast.OBDesc,
+						OrderKeyword: &ast.Keyword{Type: ast.KDesc},
+					},
+				},
+				IncludeKeyword: &ast.Keyword{Type: ast.KInclude},
+				IncludeColumns: &ast.ExprExpressionList{List: []ast.Expression{
+					&ast.ExprIdentifier{Value: "LastPrice"},
+				}},
+				WhereClause: &ast.WhereClause{
+					WhereKeyword: ast.Keyword{Type: ast.KWhere},
+					Clause: &ast.ExprComparisonOperator{
+						Left:     &ast.ExprIdentifier{Value: "LastPrice"},
+						Operator: ast.ComparisonOpGreater,
+						Right:    &ast.ExprNumberLiteral{Value: "0"},
+					},
+				},
+				WithKeyword: &ast.Keyword{Type: ast.KWith},
+				Options: []ast.IndexOption{
+					{
+						Name:  &ast.ExprIdentifier{Value: "fillfactor"},
+						Value: &ast.ExprNumberLiteral{Value: "80"},
+					},
+					{
+						Name:         &ast.ExprIdentifier{Value: "online"},
+						ValueKeyword: &ast.Keyword{Type: ast.KOn},
+					},
+				},
+			},
+		},
+		{
+			input: "create trigger trQuotes on Quotes instead of insert, delete as print 'blocked'",
+			expected: &ast.CreateTriggerStatement{
+				CreateKeywords: []ast.Keyword{{Type: ast.KCreate}},
+				TriggerKeyword: ast.Keyword{Type: ast.KTrigger},
+				Name:           &ast.ExprIdentifier{Value: "trQuotes"},
+				OnKeyword:      ast.Keyword{Type: ast.KOn},
+				Table:          &ast.ExprIdentifier{Value: "Quotes"},
+				TimingKeywords: []ast.Keyword{{Type: ast.KInstead}, {Type: ast.KOf}},
+				EventKeywords:  []ast.Keyword{{Type: ast.KInsert}, {Type: ast.KDelete}},
+				AsKeyword:      ast.Keyword{Type: ast.KAs},
+				Statements: []ast.Statement{
+					&ast.PrintStatement{
+						PrintKeyword: ast.Keyword{Type: ast.KPrint},
+						Value:        &ast.ExprStringLiteral{Value: "blocked"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		test(t, ast.Query{Batches: []ast.Batch{{Statements: []ast.Statement{tt.expected}}}}, tt.input)
+	}
+}
+
+func test(t *testing.T, expected ast.Query, input string) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+	sugar := logger.Sugar()
+	l := lexer.NewLexer(input)
+	p := NewParser(sugar, l)
+	query := p.Parse()
+
+	if len(query.Batches) != 1 {
+		t.Fatalf("expected 1 batch, got %d\n %s", len(query.Batches), strings.Join(p.Errors(), "\n"))
+	}
+	if len(query.Batches[0].Statements) != 1 {
+		t.Fatalf("expected 1 statement, got %d\n %s", len(query.Batches[0].Statements), strings.Join(p.Errors(), "\n"))
 	}
-	for i, stmt := range query.Statements {
-		if stmt.TokenLiteral() != expected.Statements[i].TokenLiteral() {
-			t.Fatalf("expected %s, got %s", expected.Statements[i].TokenLiteral(), stmt.TokenLiteral())
+	for i, stmt := range query.Batches[0].Statements {
+		if stmt.TokenLiteral() != expected.Batches[0].Statements[i].TokenLiteral() {
+			t.Fatalf("expected %s, got %s", expected.Batches[0].Statements[i].TokenLiteral(), stmt.TokenLiteral())
 		}
 	}
 }
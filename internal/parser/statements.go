@@ -6,54 +6,156 @@ import (
 
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 func (p *Parser) parseStatement() (ast.Statement, error) {
 	switch p.peekToken.Type {
-	case lexer.TSelect:
+	case lexer.TSelect, lexer.TLeftParen:
 		startPosition := p.peekToken.Start
-		body, err := p.parseSelectBody()
+		query, err := p.parseQueryExpression()
 		if err != nil {
 			return nil, err
 		}
 
 		return &ast.SelectStatement{
-			Span:       ast.NewSpanFromLexerPosition(startPosition, body.EndPosition),
-			SelectBody: &body,
+			Span:  ast.NewSpanFromLexerPosition(startPosition, query.GetSpan().EndPosition),
+			Query: query,
 		}, nil
 	case lexer.TWith:
-		select_statement, err := p.parseSelectStatement()
+		statement, err := p.parseWithStatement()
 
 		if err != nil {
 			return nil, err
 		}
-		return select_statement, nil
+		return statement, nil
+	case lexer.TInsert:
+		return p.parseInsertStatement()
+	case lexer.TUpdate:
+		return p.parseUpdateStatement()
+	case lexer.TDelete:
+		return p.parseDeleteStatement()
+	case lexer.TMerge:
+		return p.parseMergeStatement()
+	case lexer.TDeclare:
+		return p.parseDeclareStatement()
+	case lexer.TSet:
+		return p.parseSetStatement()
+	case lexer.TBegin:
+		return p.parseBeginStatement()
+	case lexer.TIf:
+		return p.parseIfStatement()
+	case lexer.TWhile:
+		return p.parseWhileStatement()
+	case lexer.TBreak:
+		breakKw, _ := p.consumeKeyword(lexer.TBreak)
+		return &ast.BreakStatement{BreakKeyword: *breakKw, Span: breakKw.Span}, nil
+	case lexer.TContinue:
+		continueKw, _ := p.consumeKeyword(lexer.TContinue)
+		return &ast.ContinueStatement{ContinueKeyword: *continueKw, Span: continueKw.Span}, nil
+	case lexer.TReturn:
+		return p.parseReturnStatement()
+	case lexer.TThrow:
+		return p.parseThrowStatement()
+	case lexer.TRaiserror:
+		return p.parseRaiserrorStatement()
+	case lexer.TPrint:
+		return p.parsePrintStatement()
+	case lexer.TCommit:
+		return p.parseCommitTransactionStatement()
+	case lexer.TRollback:
+		return p.parseRollbackTransactionStatement()
+	case lexer.TSave:
+		return p.parseSaveTransactionStatement()
+	case lexer.TExec, lexer.TExecute:
+		return p.parseExecuteStatement()
+	case lexer.TCreate:
+		return p.parseCreateStatement()
+	case lexer.TAlter:
+		return p.parseAlterStatement()
+	case lexer.TDrop:
+		return p.parseDropStatement()
 	default:
-		return nil, nil
-		// return nil, fmt.Errorf("unknown statement type %s", p.currentToken.Value)
+		return nil, fmt.Errorf("unknown statement type %s", p.peekToken.Value)
 	}
 }
 
-func (p *Parser) parseSelectStatement() (*ast.SelectStatement, error) {
-	p.logger.Debugln("parsing select statement with cte")
-	startPositionSelectStatement := p.peekToken.Start
-	withKeyword, err := p.consumeKeyword(lexer.TWith)
+func (p *Parser) parseWithStatement() (ast.Statement, error) {
+	p.logger.Debugln("parsing statement with cte")
+	startPosition := p.peekToken.Start
+	withKeyword, ctes, err := p.parseCommonTableExpressions()
 	if err != nil {
 		return nil, err
 	}
-	ctes := []ast.CommonTableExpression{}
-	for !p.peekTokenIs(lexer.TSelect) {
-		if len(ctes) > 0 {
-			if _, err := p.consumeToken(lexer.TComma); err != nil {
-				return nil, err
-			}
+
+	switch p.peekToken.Type {
+	case lexer.TInsert:
+		stmt, err := p.parseInsertStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WithKeyword = withKeyword
+		stmt.CTE = &ctes
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.EndPosition)
+		return stmt, nil
+	case lexer.TUpdate:
+		stmt, err := p.parseUpdateStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WithKeyword = withKeyword
+		stmt.CTE = &ctes
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.EndPosition)
+		return stmt, nil
+	case lexer.TDelete:
+		stmt, err := p.parseDeleteStatement()
+		if err != nil {
+			return nil, err
 		}
+		stmt.WithKeyword = withKeyword
+		stmt.CTE = &ctes
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.EndPosition)
+		return stmt, nil
+	case lexer.TMerge:
+		stmt, err := p.parseMergeStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WithKeyword = withKeyword
+		stmt.CTE = &ctes
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.EndPosition)
+		return stmt, nil
+	}
+
+	p.logger.Debugln("select body of select statement with cte")
+	query, err := p.parseQueryExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.SelectStatement{
+		WithKeyword: withKeyword,
+		CTE:         &ctes,
+		Query:       query,
+		Span: ast.NewSpanFromLexerPosition(
+			startPosition,
+			query.GetSpan().EndPosition,
+		),
+	}, nil
+}
+
+func (p *Parser) parseCommonTableExpressions() (*ast.Keyword, []ast.CommonTableExpression, error) {
+	withKeyword, err := p.consumeKeyword(lexer.TWith)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctes := []ast.CommonTableExpression{}
+	for {
 		startPosition := p.peekToken.Start
 
 		// check for expression name
 		token, err := p.consumeTokenAny([]lexer.TokenType{lexer.TIdentifier, lexer.TQuotedIdentifier})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		cteName := token.Value
@@ -63,36 +165,43 @@ func (p *Parser) parseSelectStatement() (*ast.SelectStatement, error) {
 			// parse column list
 			expressionList, err := p.parseExpressionList()
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			exprList = &expressionList
 
 			// go to the right paren
 			if _, err = p.consumeToken(lexer.TRightParen); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 
 		asKeyword, err := p.consumeKeyword(lexer.TAs)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		selectBody, err := p.parseSelectBody()
+		query, err := p.parseQueryExpression()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		if selectBody.OrderByClause != nil && len(selectBody.OrderByClause.Expressions) > 0 && selectBody.Top == nil {
-			return nil, p.peekErrorString("Order by is not allowed in cte query unless top clause is specified")
+		switch v := query.(type) {
+		case *ast.SelectBody:
+			if v.OrderByClause != nil && len(v.OrderByClause.Expressions) > 0 && v.Top == nil {
+				return nil, nil, p.peekErrorString("Order by is not allowed in cte query unless top clause is specified")
+			}
+		case *ast.SetOperation:
+			if v.OrderByClause != nil {
+				return nil, nil, p.peekErrorString("Order by is not allowed in cte query unless top clause is specified")
+			}
 		}
 		rightParen, err := p.consumeToken(lexer.TRightParen)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		cte := ast.CommonTableExpression{
@@ -100,360 +209,2620 @@ func (p *Parser) parseSelectStatement() (*ast.SelectStatement, error) {
 			Name:      cteName,
 			Columns:   exprList,
 			AsKeyword: *asKeyword,
-			Query:     selectBody,
+			Query:     query,
 		}
 		ctes = append(ctes, cte)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return withKeyword, ctes, nil
+}
+
+func (p *Parser) parseQueryExpression() (ast.QueryExpression, error) {
+	startPosition := p.peekToken.Start
+	left, err := p.parseQueryExpressionOperand()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := p.expectPeekMany([]lexer.TokenType{lexer.TSelect}); err != nil {
+	query, err := p.parseSetOperation(left, 1)
+	if err != nil {
 		return nil, err
 	}
 
-	p.logger.Debugln("select body of select statement with cte")
-	if p.peekTokenIs(lexer.TSelect) {
-		selectBody, err := p.parseSelectBody()
+	if p.peekTokenIs(lexer.TOrder) {
+		orderByClause, err := p.parseOrderByClause()
 		if err != nil {
 			return nil, err
 		}
-		return &ast.SelectStatement{
-			WithKeyword: withKeyword,
-			CTE:         &ctes,
-			SelectBody:  &selectBody,
-			Span: ast.NewSpanFromLexerPosition(
-				startPositionSelectStatement,
-				selectBody.EndPosition,
-			),
-		}, nil
+		if setOperatorPrecedence(p.peekToken.Type) > 0 {
+			return nil, p.peekErrorString("'ORDER BY' only after the last query of a set operation")
+		}
+
+		span := ast.NewSpanFromLexerPosition(startPosition, orderByClause.EndPosition)
+		switch v := query.(type) {
+		case *ast.SelectBody:
+			v.OrderByClause = orderByClause
+			v.SetSpan(span)
+		case *ast.SetOperation:
+			v.OrderByClause = orderByClause
+			v.SetSpan(span)
+		case *ast.ParenthesizedQuery:
+			v.OrderByClause = orderByClause
+			v.SetSpan(span)
+		}
 	}
-	return &ast.SelectStatement{}, nil
+
+	// FOR UPDATE belongs to a cursor declaration
+	if p.peekTokenIs(lexer.TFor) && p.peekSecondTokenIs(lexer.TIdentifier) {
+		forClause, err := p.parseForClause()
+		if err != nil {
+			return nil, err
+		}
+
+		span := ast.NewSpanFromLexerPosition(startPosition, forClause.EndPosition)
+		switch v := query.(type) {
+		case *ast.SelectBody:
+			v.ForClause = forClause
+			v.SetSpan(span)
+		case *ast.SetOperation:
+			v.ForClause = forClause
+			v.SetSpan(span)
+		case *ast.ParenthesizedQuery:
+			v.ForClause = forClause
+			v.SetSpan(span)
+		}
+	}
+
+	if p.peekTokenIs(lexer.TOption) {
+		optionClause, err := p.parseOptionClause()
+		if err != nil {
+			return nil, err
+		}
+
+		span := ast.NewSpanFromLexerPosition(startPosition, optionClause.EndPosition)
+		switch v := query.(type) {
+		case *ast.SelectBody:
+			v.OptionClause = optionClause
+			v.SetSpan(span)
+		case *ast.SetOperation:
+			v.OptionClause = optionClause
+			v.SetSpan(span)
+		case *ast.ParenthesizedQuery:
+			v.OptionClause = optionClause
+			v.SetSpan(span)
+		}
+	}
+
+	return query, nil
 }
 
-func (p *Parser) parseTopArg(topKw ast.Keyword) (*ast.TopArg, error) {
-	topArg := ast.TopArg{}
-	topArg.TopKeyword = topKw
-	startPosition := topKw.StartPosition
-	p.logger.Debug(p.peekToken)
-	numericLiteral, err := p.consumeToken(lexer.TNumericLiteral)
+var for_clause_modes = []string{"xml", "json", "browse"}
+
+var for_options = []string{
+	"raw",
+	"auto",
+	"explicit",
+	"path",
+	"type",
+	"elements",
+	"root",
+	"binary",
+	"include_null_values",
+	"without_array_wrapper",
+}
+
+func (p *Parser) parseForClause() (*ast.ForClause, error) {
+	forKw, err := p.consumeKeyword(lexer.TFor)
 	if err != nil {
 		return nil, err
 	}
-	expr := &ast.ExprNumberLiteral{
-		Value: numericLiteral.Value,
-		Span:  ast.NewSpanFromToken(*numericLiteral),
+
+	var modeKw *ast.Keyword
+	for _, mode := range for_clause_modes {
+		if modeKw = p.maybeIdentifierKeyword(mode); modeKw != nil {
+			break
+		}
+	}
+	if modeKw == nil {
+		return nil, p.peekErrorString("XML or JSON or BROWSE")
+	}
+	forClause := &ast.ForClause{
+		ForKeyword:  *forKw,
+		ModeKeyword: *modeKw,
+		Span:        ast.NewSpanFromLexerPosition(forKw.StartPosition, modeKw.EndPosition),
+	}
+	if modeKw.Type == ast.KBrowse {
+		return forClause, nil
 	}
-	topArg.Quantity = expr
-	topArg.Span = ast.NewSpanFromLexerPosition(startPosition, expr.EndPosition)
 
-	if kw := p.maybeKeyword(lexer.TPercent); kw != nil {
-		topArg.PercentKeyword = kw
-		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, kw.EndPosition)
+	for {
+		option, err := p.parseForOption()
+		if err != nil {
+			return nil, err
+		}
+		forClause.Options = append(forClause.Options, *option)
+		forClause.Span = ast.NewSpanFromLexerPosition(forKw.StartPosition, option.EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
 	}
 
-	if withKw := p.maybeKeyword(lexer.TWith); withKw != nil {
-		tiesKw, err := p.consumeKeyword(lexer.TTies)
+	return forClause, nil
+}
+
+func (p *Parser) parseForOption() (*ast.ForOption, error) {
+	var kw *ast.Keyword
+	for _, option := range for_options {
+		if kw = p.maybeIdentifierKeyword(option); kw != nil {
+			break
+		}
+	}
+	if kw == nil {
+		return nil, p.peekErrorString("FOR XML or FOR JSON option")
+	}
+	option := &ast.ForOption{Keywords: []ast.Keyword{*kw}, Span: kw.Span}
+
+	switch kw.Type {
+	case ast.KRaw, ast.KPath, ast.KRoot:
+		// the element name is optional, PATH('') removes the wrapping element
+		if leftParen := p.maybeToken(lexer.TLeftParen); leftParen == nil {
+			break
+		}
+		value, err := p.parseExpression(PrecedenceLowest)
 		if err != nil {
 			return nil, err
 		}
-		topArg.WithTiesKeyword = &[2]ast.Keyword{*withKw, *tiesKw}
-		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, tiesKw.EndPosition)
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		option.Value = value
+		option.Span = ast.NewSpanFromLexerPosition(kw.StartPosition, rightParen.End)
+	case ast.KElements:
+		if nextKw := p.maybeIdentifierKeyword("xsinil"); nextKw != nil {
+			option.Keywords = append(option.Keywords, *nextKw)
+			option.Span = ast.NewSpanFromLexerPosition(kw.StartPosition, nextKw.EndPosition)
+		} else if nextKw := p.maybeIdentifierKeyword("absent"); nextKw != nil {
+			option.Keywords = append(option.Keywords, *nextKw)
+			option.Span = ast.NewSpanFromLexerPosition(kw.StartPosition, nextKw.EndPosition)
+		}
+	case ast.KBinary:
+		base64Kw := p.maybeIdentifierKeyword("base64")
+		if base64Kw == nil {
+			return nil, p.peekErrorString("Base64")
+		}
+		option.Keywords = append(option.Keywords, *base64Kw)
+		option.Span = ast.NewSpanFromLexerPosition(kw.StartPosition, base64Kw.EndPosition)
 	}
 
-	return &topArg, nil
+	return option, nil
 }
 
-func (p *Parser) parseSelectBody() (ast.SelectBody, error) {
-	stmt := ast.SelectBody{}
-	startPositionSelectBody := p.peekToken.Start
-	kw, err := p.consumeKeyword(lexer.TSelect)
+func (p *Parser) parseOptionClause() (*ast.OptionClause, error) {
+	optionKw, err := p.consumeKeyword(lexer.TOption)
 	if err != nil {
-		return stmt, err
+		return nil, err
 	}
-	stmt.SelectKeyword = *kw
-	if kw := p.maybeKeyword(lexer.TDistinct); kw != nil {
-		stmt.DistinctKeyword = kw
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
 	}
 
-	// check for optional all keyword
-	if kw := p.maybeKeyword(lexer.TAll); kw != nil {
-		stmt.AllKeyword = kw
+	optionClause := &ast.OptionClause{OptionKeyword: *optionKw}
+	for {
+		hint, err := p.parseQueryHint()
+		if err != nil {
+			return nil, err
+		}
+		optionClause.Hints = append(optionClause.Hints, *hint)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
 	}
+	optionClause.Span = ast.NewSpanFromLexerPosition(optionKw.StartPosition, rightParen.End)
 
-	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
-		topArg, err := p.parseTopArg(*kw)
+	return optionClause, nil
+}
+
+func (p *Parser) parseQueryHint() (*ast.QueryHint, error) {
+	if kw := p.maybeIdentifierKeyword("recompile"); kw != nil {
+		return &ast.QueryHint{Keywords: []ast.Keyword{*kw}, Span: kw.Span}, nil
+	}
+
+	// hints that take a number like MAXDOP 1
+	for _, name := range []string{"maxdop", "fast", "maxrecursion"} {
+		if kw := p.maybeIdentifierKeyword(name); kw != nil {
+			token, err := p.consumeToken(lexer.TNumericLiteral)
+			if err != nil {
+				return nil, err
+			}
+			return &ast.QueryHint{
+				Keywords: []ast.Keyword{*kw},
+				Value:    &ast.ExprNumberLiteral{Value: token.Value, Span: ast.NewSpanFromToken(*token)},
+				Span:     ast.NewSpanFromLexerPosition(kw.StartPosition, token.End),
+			}, nil
+		}
+	}
+
+	if optimizeKw := p.maybeIdentifierKeyword("optimize"); optimizeKw != nil {
+		return p.parseOptimizeForHint(*optimizeKw)
+	}
+	if forceKw := p.maybeIdentifierKeyword("force"); forceKw != nil {
+		orderKw, err := p.consumeKeyword(lexer.TOrder)
 		if err != nil {
-			return stmt, err
+			return nil, err
 		}
-		stmt.Top = topArg
+		return &ast.QueryHint{
+			Keywords: []ast.Keyword{*forceKw, *orderKw},
+			Span:     ast.NewSpanFromLexerPosition(forceKw.StartPosition, orderKw.EndPosition),
+		}, nil
+	}
+	if keepfixedKw := p.maybeIdentifierKeyword("keepfixed"); keepfixedKw != nil {
+		planKw := p.maybeIdentifierKeyword("plan")
+		if planKw == nil {
+			return nil, p.peekErrorString("PLAN")
+		}
+		return &ast.QueryHint{
+			Keywords: []ast.Keyword{*keepfixedKw, *planKw},
+			Span:     ast.NewSpanFromLexerPosition(keepfixedKw.StartPosition, planKw.EndPosition),
+		}, nil
 	}
 
-	selectItems, err := p.parseSelectItems()
+	// join, group and union hints like HASH JOIN or ORDER GROUP
+	var strategyKw *ast.Keyword
+	if kw := p.maybeIdentifierKeyword("hash"); kw != nil {
+		strategyKw = kw
+	} else if kw := p.maybeIdentifierKeyword("loop"); kw != nil {
+		strategyKw = kw
+	} else if kw := p.maybeKeyword(lexer.TMerge); kw != nil {
+		strategyKw = kw
+	} else if kw := p.maybeKeyword(lexer.TOrder); kw != nil {
+		strategyKw = kw
+	} else {
+		return nil, p.peekErrorString("query hint")
+	}
+	operationKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TJoin, lexer.TGroup, lexer.TUnion})
 	if err != nil {
-		return stmt, err
+		return nil, err
 	}
-	stmt.SelectItems = *selectItems
-	stmt.Span = ast.NewSpanFromLexerPosition(
-		startPositionSelectBody,
-		selectItems.EndPosition,
-	)
 
-	table, err := p.parseTableArg()
+	return &ast.QueryHint{
+		Keywords: []ast.Keyword{*strategyKw, *operationKw},
+		Span:     ast.NewSpanFromLexerPosition(strategyKw.StartPosition, operationKw.EndPosition),
+	}, nil
+}
+
+// parses OPTIMIZE FOR UNKNOWN and OPTIMIZE FOR (@p UNKNOWN, @q = 1)
+func (p *Parser) parseOptimizeForHint(optimizeKw ast.Keyword) (*ast.QueryHint, error) {
+	forKw, err := p.consumeKeyword(lexer.TFor)
 	if err != nil {
-		return stmt, err
+		return nil, err
 	}
-	stmt.Table = table
-	stmt.Span = ast.NewSpanFromLexerPosition(
-		startPositionSelectBody,
-		table.EndPosition,
-	)
+	hint := &ast.QueryHint{Keywords: []ast.Keyword{optimizeKw, *forKw}}
 
-	if p.peekTokenIs(lexer.TWhere) {
-		whereExpression, err := p.parseWhereExpression()
+	if unknownKw := p.maybeIdentifierKeyword("unknown"); unknownKw != nil {
+		hint.Keywords = append(hint.Keywords, *unknownKw)
+		hint.Span = ast.NewSpanFromLexerPosition(optimizeKw.StartPosition, unknownKw.EndPosition)
+		return hint, nil
+	}
+
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	for {
+		nameToken, err := p.consumeToken(lexer.TLocalVariable)
 		if err != nil {
-			return stmt, err
+			return nil, err
 		}
-		stmt.WhereClause = whereExpression
-		stmt.Span = ast.NewSpanFromLexerPosition(
-			startPositionSelectBody,
-			whereExpression.EndPosition,
-		)
+		variable := ast.OptimizeForVariable{
+			Variable: ast.ExprLocalVariable{Value: nameToken.Value, Span: ast.NewSpanFromToken(*nameToken)},
+		}
+		if unknownKw := p.maybeIdentifierKeyword("unknown"); unknownKw != nil {
+			variable.UnknownKeyword = unknownKw
+			variable.Span = ast.NewSpanFromLexerPosition(nameToken.Start, unknownKw.EndPosition)
+		} else {
+			if _, err := p.consumeToken(lexer.TEqual); err != nil {
+				return nil, err
+			}
+			variable.Value, err = p.parseExpression(PrecedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			variable.Span = ast.NewSpanFromLexerPosition(nameToken.Start, variable.Value.GetSpan().EndPosition)
+		}
+		hint.Variables = append(hint.Variables, variable)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
 	}
+	hint.Span = ast.NewSpanFromLexerPosition(optimizeKw.StartPosition, rightParen.End)
 
-	if p.peekTokenIs(lexer.TGroup) {
-		groupByClause, err := p.parseGroupByClause()
+	return hint, nil
+}
+
+func (p *Parser) parseQueryExpressionOperand() (ast.QueryExpression, error) {
+	if leftParen := p.maybeToken(lexer.TLeftParen); leftParen != nil {
+		query, err := p.parseQueryExpression()
 		if err != nil {
-			return stmt, err
+			return nil, err
 		}
-		stmt.GroupByClause = groupByClause
-		stmt.Span = ast.NewSpanFromLexerPosition(
-			startPositionSelectBody,
-			groupByClause.EndPosition,
-		)
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.ParenthesizedQuery{
+			Query: query,
+			Span:  ast.NewSpanFromLexerPosition(leftParen.Start, rightParen.End),
+		}, nil
+	}
+
+	body, err := p.parseSelectBodyWithoutOrderBy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &body, nil
+}
+
+func (p *Parser) parseSetOperation(left ast.QueryExpression, minPrecedence int) (ast.QueryExpression, error) {
+	for setOperatorPrecedence(p.peekToken.Type) >= minPrecedence {
+		precedence := setOperatorPrecedence(p.peekToken.Type)
+		operatorKeywords := []ast.Keyword{}
+		var operator ast.SetOperatorType
+		if kw := p.maybeKeyword(lexer.TUnion); kw != nil {
+			operatorKeywords = append(operatorKeywords, *kw)
+			if kw := p.maybeKeyword(lexer.TAll); kw != nil {
+				operatorKeywords = append(operatorKeywords, *kw)
+				operator = ast.SOUnionAll
+			} else {
+				operator = ast.SOUnion
+			}
+		} else if kw := p.maybeKeyword(lexer.TExcept); kw != nil {
+			operatorKeywords = append(operatorKeywords, *kw)
+			operator = ast.SOExcept
+		} else if kw := p.maybeKeyword(lexer.TIntersect); kw != nil {
+			operatorKeywords = append(operatorKeywords, *kw)
+			operator = ast.SOIntersect
+		}
+
+		if err := p.expectPeekMany([]lexer.TokenType{lexer.TSelect, lexer.TLeftParen}); err != nil {
+			return nil, err
+		}
+		right, err := p.parseQueryExpressionOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		for setOperatorPrecedence(p.peekToken.Type) > precedence {
+			right, err = p.parseSetOperation(right, precedence+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		left = &ast.SetOperation{
+			Left:             left,
+			OperatorKeywords: operatorKeywords,
+			Operator:         operator,
+			Right:            right,
+			Span: ast.NewSpanFromLexerPosition(
+				left.GetSpan().StartPosition,
+				right.GetSpan().EndPosition,
+			),
+		}
+	}
+
+	return left, nil
+}
+
+func setOperatorPrecedence(t lexer.TokenType) int {
+	switch t {
+	case lexer.TIntersect:
+		return 2
+	case lexer.TUnion, lexer.TExcept:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (p *Parser) parseInsertStatement() (*ast.InsertStatement, error) {
+	startPosition := p.peekToken.Start
+	insertKw, err := p.consumeKeyword(lexer.TInsert)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.InsertStatement{InsertKeyword: *insertKw}
+
+	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
+		topArg, err := p.parseParenthesizedTopArg(*kw)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Top = topArg
+	}
+
+	stmt.IntoKeyword = p.maybeKeyword(lexer.TInto)
+
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	target, err = p.parseTargetTableHints(target)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Target = target
+
+	if p.peekTokenIs(lexer.TLeftParen) {
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Columns = columns
+	}
+
+	if p.peekTokenIs(lexer.TOutput) {
+		stmt.OutputClause, err = p.parseOutputClause()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var endPosition lexer.Position
+	switch p.peekToken.Type {
+	case lexer.TValues:
+		values, err := p.parseTableValueConstructor()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Values = values
+		endPosition = values.EndPosition
+	case lexer.TSelect, lexer.TLeftParen:
+		query, err := p.parseQueryExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Query = query
+		endPosition = query.GetSpan().EndPosition
+	case lexer.TExec, lexer.TExecute:
+		execute, err := p.parseExecuteStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Execute = execute
+		endPosition = execute.EndPosition
+	case lexer.TDefault:
+		defaultKw, _ := p.consumeKeyword(lexer.TDefault)
+		valuesKw, err := p.consumeKeyword(lexer.TValues)
+		if err != nil {
+			return nil, err
+		}
+		stmt.DefaultValuesKeyword = &[2]ast.Keyword{*defaultKw, *valuesKw}
+		endPosition = valuesKw.EndPosition
+	default:
+		return nil, p.peekErrorMany([]lexer.TokenType{
+			lexer.TValues,
+			lexer.TSelect,
+			lexer.TExec,
+			lexer.TDefault,
+		})
+	}
+	stmt.Span = ast.NewSpanFromLexerPosition(startPosition, endPosition)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseUpdateStatement() (*ast.UpdateStatement, error) {
+	startPosition := p.peekToken.Start
+	updateKw, err := p.consumeKeyword(lexer.TUpdate)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.UpdateStatement{UpdateKeyword: *updateKw}
+
+	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
+		topArg, err := p.parseParenthesizedTopArg(*kw)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Top = topArg
+	}
+
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	target, err = p.parseTargetTableHints(target)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Target = target
+
+	setKw, err := p.consumeKeyword(lexer.TSet)
+	if err != nil {
+		return nil, err
+	}
+	stmt.SetKeyword = *setKw
+
+	for {
+		assignment, err := p.parseAssignment()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Assignments = append(stmt.Assignments, assignment)
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, assignment.EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	if p.peekTokenIs(lexer.TOutput) {
+		stmt.OutputClause, err = p.parseOutputClause()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.OutputClause.EndPosition)
+	}
+
+	if p.peekTokenIs(lexer.TFrom) {
+		table, err := p.parseTableArg()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Table = table
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, table.EndPosition)
+	}
+
+	if p.peekTokenIs(lexer.TWhere) {
+		whereClause, err := p.parseWhereExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WhereClause = whereClause
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, whereClause.EndPosition)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseDeleteStatement() (*ast.DeleteStatement, error) {
+	startPosition := p.peekToken.Start
+	deleteKw, err := p.consumeKeyword(lexer.TDelete)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.DeleteStatement{DeleteKeyword: *deleteKw}
+
+	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
+		topArg, err := p.parseParenthesizedTopArg(*kw)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Top = topArg
+	}
+
+	stmt.FromKeyword = p.maybeKeyword(lexer.TFrom)
+
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	target, err = p.parseTargetTableHints(target)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Target = target
+	stmt.Span = ast.NewSpanFromLexerPosition(startPosition, target.GetSpan().EndPosition)
+
+	if p.peekTokenIs(lexer.TOutput) {
+		stmt.OutputClause, err = p.parseOutputClause()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, stmt.OutputClause.EndPosition)
+	}
+
+	if p.peekTokenIs(lexer.TFrom) {
+		table, err := p.parseTableArg()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Table = table
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, table.EndPosition)
+	}
+
+	if p.peekTokenIs(lexer.TWhere) {
+		whereClause, err := p.parseWhereExpression()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WhereClause = whereClause
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, whereClause.EndPosition)
+	}
+
+	return stmt, nil
+}
+
+// BEGIN starts either a statement block or a try...catch
+func (p *Parser) parseBeginStatement() (ast.Statement, error) {
+	beginKw, err := p.consumeKeyword(lexer.TBegin)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.peekToken.Type {
+	case lexer.TTry:
+		return p.parseTryCatchStatement(*beginKw)
+	case lexer.TTran, lexer.TTransaction:
+		transactionKw, _ := p.consumeKeywordAny([]lexer.TokenType{lexer.TTran, lexer.TTransaction})
+		stmt := &ast.BeginTransactionStatement{
+			BeginKeyword:       *beginKw,
+			TransactionKeyword: *transactionKw,
+			Span:               ast.NewSpanFromLexerPosition(beginKw.StartPosition, transactionKw.EndPosition),
+		}
+		if name := p.maybeTransactionName(); name != nil {
+			stmt.Name = name
+			stmt.Span = ast.NewSpanFromLexerPosition(beginKw.StartPosition, name.GetSpan().EndPosition)
+		}
+		return stmt, nil
+	}
+
+	return p.parseBlockStatement(*beginKw)
+}
+
+func (p *Parser) parseBlockStatement(beginKw ast.Keyword) (*ast.BlockStatement, error) {
+	statements, err := p.parseStatementsUntilEnd()
+	if err != nil {
+		return nil, err
+	}
+
+	endKw, _ := p.consumeKeyword(lexer.TEnd)
+	return &ast.BlockStatement{
+		BeginKeyword: beginKw,
+		Statements:   statements,
+		EndKeyword:   *endKw,
+		Span:         ast.NewSpanFromLexerPosition(beginKw.StartPosition, endKw.EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseTryCatchStatement(beginKw ast.Keyword) (*ast.TryCatchStatement, error) {
+	stmt := &ast.TryCatchStatement{}
+	tryKw, err := p.consumeKeyword(lexer.TTry)
+	if err != nil {
+		return nil, err
+	}
+	stmt.BeginTryKeywords = [2]ast.Keyword{beginKw, *tryKw}
+
+	stmt.TryStatements, err = p.parseStatementsUntilEnd()
+	if err != nil {
+		return nil, err
+	}
+	endKw, _ := p.consumeKeyword(lexer.TEnd)
+	tryKw, err = p.consumeKeyword(lexer.TTry)
+	if err != nil {
+		return nil, err
+	}
+	stmt.EndTryKeywords = [2]ast.Keyword{*endKw, *tryKw}
+
+	beginCatchKw, err := p.consumeKeyword(lexer.TBegin)
+	if err != nil {
+		return nil, err
+	}
+	catchKw, err := p.consumeKeyword(lexer.TCatch)
+	if err != nil {
+		return nil, err
+	}
+	stmt.BeginCatchKeywords = [2]ast.Keyword{*beginCatchKw, *catchKw}
+
+	stmt.CatchStatements, err = p.parseStatementsUntilEnd()
+	if err != nil {
+		return nil, err
+	}
+	endKw, _ = p.consumeKeyword(lexer.TEnd)
+	catchKw, err = p.consumeKeyword(lexer.TCatch)
+	if err != nil {
+		return nil, err
+	}
+	stmt.EndCatchKeywords = [2]ast.Keyword{*endKw, *catchKw}
+	stmt.Span = ast.NewSpanFromLexerPosition(beginKw.StartPosition, catchKw.EndPosition)
+
+	return stmt, nil
+}
+
+// parses statements up to, but not including, the END keyword
+func (p *Parser) parseStatementsUntilEnd() ([]ast.Statement, error) {
+	statements := []ast.Statement{}
+	for !p.peekTokenIs(lexer.TEnd) {
+		if p.peekTokenIs(lexer.TEndOfFile) {
+			return nil, p.peekErrorString(lexer.TEnd.String())
+		}
+		if token := p.maybeToken(lexer.TSemiColon); token != nil {
+			continue
+		}
+
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+
+	return statements, nil
+}
+
+func (p *Parser) parseIfStatement() (*ast.IfStatement, error) {
+	startPosition := p.peekToken.Start
+	ifKw, err := p.consumeKeyword(lexer.TIf)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	then, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.IfStatement{
+		IfKeyword: *ifKw,
+		Condition: condition,
+		Then:      then,
+		Span:      ast.NewSpanFromLexerPosition(startPosition, then.GetSpan().EndPosition),
+	}
+
+	// a statement terminator is allowed before the else branch
+	p.maybeToken(lexer.TSemiColon)
+	if elseKw := p.maybeKeyword(lexer.TElse); elseKw != nil {
+		elseStmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmt.ElseKeyword = elseKw
+		stmt.Else = elseStmt
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, elseStmt.GetSpan().EndPosition)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseWhileStatement() (*ast.WhileStatement, error) {
+	startPosition := p.peekToken.Start
+	whileKw, err := p.consumeKeyword(lexer.TWhile)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.WhileStatement{
+		WhileKeyword: *whileKw,
+		Condition:    condition,
+		Body:         body,
+		Span:         ast.NewSpanFromLexerPosition(startPosition, body.GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseReturnStatement() (*ast.ReturnStatement, error) {
+	returnKw, err := p.consumeKeyword(lexer.TReturn)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.ReturnStatement{ReturnKeyword: *returnKw, Span: returnKw.Span}
+
+	if p.expectFunctionArgsStart() != nil {
+		return stmt, nil
+	}
+
+	value, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Value = value
+	stmt.Span = ast.NewSpanFromLexerPosition(returnKw.StartPosition, value.GetSpan().EndPosition)
+
+	return stmt, nil
+}
+
+var transaction_keywords = []lexer.TokenType{lexer.TTran, lexer.TTransaction, lexer.TWork}
+
+func (p *Parser) parseCommitTransactionStatement() (*ast.CommitTransactionStatement, error) {
+	commitKw, err := p.consumeKeyword(lexer.TCommit)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.CommitTransactionStatement{CommitKeyword: *commitKw, Span: commitKw.Span}
+
+	if kw, err := p.consumeKeywordAny(transaction_keywords); err == nil {
+		stmt.TransactionKeyword = kw
+		stmt.Span = ast.NewSpanFromLexerPosition(commitKw.StartPosition, kw.EndPosition)
+
+		if kw.Type == ast.KWork {
+			return stmt, nil
+		}
+		if name := p.maybeTransactionName(); name != nil {
+			stmt.Name = name
+			stmt.Span = ast.NewSpanFromLexerPosition(commitKw.StartPosition, name.GetSpan().EndPosition)
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseRollbackTransactionStatement() (*ast.RollbackTransactionStatement, error) {
+	rollbackKw, err := p.consumeKeyword(lexer.TRollback)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.RollbackTransactionStatement{RollbackKeyword: *rollbackKw, Span: rollbackKw.Span}
+
+	if kw, err := p.consumeKeywordAny(transaction_keywords); err == nil {
+		stmt.TransactionKeyword = kw
+		stmt.Span = ast.NewSpanFromLexerPosition(rollbackKw.StartPosition, kw.EndPosition)
+
+		// the name is either the transaction or a savepoint
+		if kw.Type == ast.KWork {
+			return stmt, nil
+		}
+		if name := p.maybeTransactionName(); name != nil {
+			stmt.Name = name
+			stmt.Span = ast.NewSpanFromLexerPosition(rollbackKw.StartPosition, name.GetSpan().EndPosition)
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseSaveTransactionStatement() (*ast.SaveTransactionStatement, error) {
+	saveKw, err := p.consumeKeyword(lexer.TSave)
+	if err != nil {
+		return nil, err
+	}
+	transactionKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TTran, lexer.TTransaction})
+	if err != nil {
+		return nil, err
+	}
+	name := p.maybeTransactionName()
+	if name == nil {
+		return nil, p.peekErrorString("savepoint name")
+	}
+
+	return &ast.SaveTransactionStatement{
+		SaveKeyword:        *saveKw,
+		TransactionKeyword: *transactionKw,
+		Name:               name,
+		Span:               ast.NewSpanFromLexerPosition(saveKw.StartPosition, name.GetSpan().EndPosition),
+	}, nil
+}
+
+// transaction and savepoint names are identifiers or variables holding the name
+func (p *Parser) maybeTransactionName() ast.Expression {
+	if token := p.maybeToken(lexer.TIdentifier); token != nil {
+		return &ast.ExprIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
+		return &ast.ExprQuotedIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else if token := p.maybeToken(lexer.TLocalVariable); token != nil {
+		return &ast.ExprLocalVariable{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	}
+
+	return nil
+}
+
+func (p *Parser) parseThrowStatement() (*ast.ThrowStatement, error) {
+	throwKw, err := p.consumeKeyword(lexer.TThrow)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.ThrowStatement{ThrowKeyword: *throwKw, Span: throwKw.Span}
+
+	// rethrow the caught error
+	if p.expectFunctionArgsStart() != nil {
+		return stmt, nil
+	}
+
+	args := make([]ast.Expression, 3)
+	for i := range args {
+		if i > 0 {
+			if _, err := p.consumeToken(lexer.TComma); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectFunctionArgsStart(); err != nil {
+			return nil, err
+		}
+		args[i], err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stmt.ErrorNumber = args[0]
+	stmt.Message = args[1]
+	stmt.State = args[2]
+	stmt.Span = ast.NewSpanFromLexerPosition(throwKw.StartPosition, args[2].GetSpan().EndPosition)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseRaiserrorStatement() (*ast.RaiserrorStatement, error) {
+	raiserrorKw, err := p.consumeKeyword(lexer.TRaiserror)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	args, err := p.parseFunctionArgs()
+	if err != nil {
+		return nil, err
+	}
+	if len(*args) < 3 {
+		return nil, fmt.Errorf("'RAISERROR' expects a message, severity and state")
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.RaiserrorStatement{
+		RaiserrorKeyword: *raiserrorKw,
+		Arguments:        *args,
+		Span:             ast.NewSpanFromLexerPosition(raiserrorKw.StartPosition, rightParen.End),
+	}
+
+	if withKw := p.maybeKeyword(lexer.TWith); withKw != nil {
+		stmt.WithKeyword = withKw
+		for {
+			var option *ast.Keyword
+			if kw := p.maybeIdentifierKeyword("nowait"); kw != nil {
+				option = kw
+			} else if kw := p.maybeIdentifierKeyword("seterror"); kw != nil {
+				option = kw
+			} else if kw := p.maybeKeyword(lexer.TLog); kw != nil {
+				option = kw
+			} else {
+				return nil, p.peekErrorString("LOG, NOWAIT or SETERROR")
+			}
+			stmt.Options = append(stmt.Options, *option)
+			stmt.Span = ast.NewSpanFromLexerPosition(raiserrorKw.StartPosition, option.EndPosition)
+
+			if token := p.maybeToken(lexer.TComma); token == nil {
+				break
+			}
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parsePrintStatement() (*ast.PrintStatement, error) {
+	printKw, err := p.consumeKeyword(lexer.TPrint)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectFunctionArgsStart(); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.PrintStatement{
+		PrintKeyword: *printKw,
+		Value:        value,
+		Span:         ast.NewSpanFromLexerPosition(printKw.StartPosition, value.GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseDeclareStatement() (*ast.DeclareStatement, error) {
+	startPosition := p.peekToken.Start
+	declareKw, err := p.consumeKeyword(lexer.TDeclare)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.DeclareStatement{DeclareKeyword: *declareKw}
+
+	// cursor names are plain identifiers
+	if p.peekTokenIsAny([]lexer.TokenType{lexer.TIdentifier, lexer.TQuotedIdentifier}) {
+		cursor, err := p.parseCursorDeclaration()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Cursor = cursor
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, cursor.EndPosition)
+		return stmt, nil
+	}
+
+	for {
+		declStartPosition := p.peekToken.Start
+		nameToken, err := p.consumeToken(lexer.TLocalVariable)
+		if err != nil {
+			return nil, err
+		}
+		name := ast.ExprLocalVariable{
+			Value: nameToken.Value,
+			Span:  ast.NewSpanFromToken(*nameToken),
+		}
+		asKw := p.maybeKeyword(lexer.TAs)
+
+		if tableKw := p.maybeKeyword(lexer.TTable); tableKw != nil {
+			if len(stmt.Variables) > 0 {
+				return nil, fmt.Errorf("table variables must be declared in their own 'DECLARE' statement")
+			}
+			definitions, rightParen, err := p.parseTableElements()
+			if err != nil {
+				return nil, err
+			}
+			stmt.TableVariable = &ast.TableVariableDeclaration{
+				Name:         name,
+				AsKeyword:    asKw,
+				TableKeyword: *tableKw,
+				Definitions:  definitions,
+				Span:         ast.NewSpanFromLexerPosition(declStartPosition, rightParen.End),
+			}
+			stmt.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+			return stmt, nil
+		}
+
+		dataType, err := p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+		variable := ast.VariableDeclaration{
+			Name:      name,
+			AsKeyword: asKw,
+			DataType:  dataType,
+			Span:      ast.NewSpanFromLexerPosition(declStartPosition, dataType.EndPosition),
+		}
+
+		if token := p.maybeToken(lexer.TEqual); token != nil {
+			value, err := p.parseExpression(PrecedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			variable.Value = value
+			variable.Span = ast.NewSpanFromLexerPosition(declStartPosition, value.GetSpan().EndPosition)
+		}
+		stmt.Variables = append(stmt.Variables, variable)
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, variable.EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseCursorDeclaration() (*ast.CursorDeclaration, error) {
+	startPosition := p.peekToken.Start
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	cursorKw, err := p.consumeKeyword(lexer.TCursor)
+	if err != nil {
+		return nil, err
+	}
+	forKw, err := p.consumeKeyword(lexer.TFor)
+	if err != nil {
+		return nil, err
+	}
+	query, err := p.parseQueryExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.CursorDeclaration{
+		Name:          name,
+		CursorKeyword: *cursorKw,
+		ForKeyword:    *forKw,
+		Query:         query,
+		Span:          ast.NewSpanFromLexerPosition(startPosition, query.GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseSetStatement() (ast.Statement, error) {
+	startPosition := p.peekToken.Start
+	setKw, err := p.consumeKeyword(lexer.TSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.peekTokenIs(lexer.TLocalVariable) {
+		return p.parseSetOptionStatement(*setKw)
+	}
+
+	variableToken, err := p.consumeToken(lexer.TLocalVariable)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := p.parseLocalVariableAssignment(&ast.ExprLocalVariable{
+		Value: variableToken.Value,
+		Span:  ast.NewSpanFromToken(*variableToken),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.SetLocalVariableStatement{
+		SetKeyword: *setKw,
+		Assignment: assignment,
+		Span:       ast.NewSpanFromLexerPosition(startPosition, assignment.EndPosition),
+	}, nil
+}
+
+// the session options that are set with ON or OFF
+var set_options = []string{
+	"ansi_defaults",
+	"ansi_null_dflt_off",
+	"ansi_null_dflt_on",
+	"ansi_nulls",
+	"ansi_padding",
+	"ansi_warnings",
+	"arithabort",
+	"arithignore",
+	"concat_null_yields_null",
+	"cursor_close_on_commit",
+	"fmtonly",
+	"forceplan",
+	"implicit_transactions",
+	"nocount",
+	"noexec",
+	"numeric_roundabort",
+	"parseonly",
+	"quoted_identifier",
+	"remote_proc_transactions",
+	"showplan_all",
+	"showplan_text",
+	"showplan_xml",
+	"xact_abort",
+}
+
+func (p *Parser) parseSetOptionStatement(setKw ast.Keyword) (*ast.SetOptionStatement, error) {
+	stmt := &ast.SetOptionStatement{SetKeyword: setKw}
+
+	for {
+		var option *ast.Keyword
+		for _, name := range set_options {
+			if option = p.maybeIdentifierKeyword(name); option != nil {
+				break
+			}
+		}
+		if option == nil {
+			return nil, p.peekErrorString("SET option")
+		}
+		stmt.Options = append(stmt.Options, *option)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	var value *ast.Keyword
+	if value = p.maybeKeyword(lexer.TOn); value == nil {
+		if value = p.maybeIdentifierKeyword("off"); value == nil {
+			return nil, p.peekErrorString("ON or OFF")
+		}
+	}
+	stmt.Value = *value
+	stmt.Span = ast.NewSpanFromLexerPosition(setKw.StartPosition, value.EndPosition)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseMergeStatement() (*ast.MergeStatement, error) {
+	startPosition := p.peekToken.Start
+	mergeKw, err := p.consumeKeyword(lexer.TMerge)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.MergeStatement{MergeKeyword: *mergeKw}
+
+	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
+		topArg, err := p.parseParenthesizedTopArg(*kw)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Top = topArg
+	}
+
+	stmt.IntoKeyword = p.maybeKeyword(lexer.TInto)
+
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	target, err = p.parseTargetTableHints(target)
+	if err != nil {
+		return nil, err
+	}
+	target, err = p.parseTableAlias(target)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Target = target
+
+	usingKw, err := p.consumeKeyword(lexer.TUsing)
+	if err != nil {
+		return nil, err
+	}
+	stmt.UsingKeyword = *usingKw
+
+	source, err := p.parseTableSource()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Source = source
+
+	onKw, err := p.consumeKeyword(lexer.TOn)
+	if err != nil {
+		return nil, err
+	}
+	stmt.OnKeyword = *onKw
+
+	condition, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Condition = condition
+
+	if err := p.expectPeek(lexer.TWhen); err != nil {
+		return nil, err
+	}
+	for p.peekTokenIs(lexer.TWhen) {
+		whenClause, err := p.parseMergeWhenClause()
+		if err != nil {
+			return nil, err
+		}
+		stmt.WhenClauses = append(stmt.WhenClauses, *whenClause)
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, whenClause.EndPosition)
+	}
+
+	if p.peekTokenIs(lexer.TOutput) {
+		outputClause, err := p.parseOutputClause()
+		if err != nil {
+			return nil, err
+		}
+		stmt.OutputClause = outputClause
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, outputClause.EndPosition)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseMergeWhenClause() (*ast.MergeWhenClause, error) {
+	startPosition := p.peekToken.Start
+	whenKw, err := p.consumeKeyword(lexer.TWhen)
+	if err != nil {
+		return nil, err
+	}
+	clause := &ast.MergeWhenClause{WhenKeywords: []ast.Keyword{*whenKw}}
+
+	notKw := p.maybeKeyword(lexer.TNot)
+	if notKw != nil {
+		clause.WhenKeywords = append(clause.WhenKeywords, *notKw)
+	}
+	matchedKw, err := p.consumeKeyword(lexer.TMatched)
+	if err != nil {
+		return nil, err
+	}
+	clause.WhenKeywords = append(clause.WhenKeywords, *matchedKw)
+
+	clause.Type = ast.MWMatched
+	if notKw != nil {
+		clause.Type = ast.MWNotMatchedByTarget
+		if byKw := p.maybeKeyword(lexer.TBy); byKw != nil {
+			clause.WhenKeywords = append(clause.WhenKeywords, *byKw)
+			if kw := p.maybeIdentifierKeyword("target"); kw != nil {
+				clause.WhenKeywords = append(clause.WhenKeywords, *kw)
+			} else if kw := p.maybeIdentifierKeyword("source"); kw != nil {
+				clause.Type = ast.MWNotMatchedBySource
+				clause.WhenKeywords = append(clause.WhenKeywords, *kw)
+			} else {
+				return nil, p.peekErrorString("TARGET or SOURCE")
+			}
+		}
+	}
+
+	if andKw := p.maybeKeyword(lexer.TAnd); andKw != nil {
+		condition, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		clause.AndKeyword = andKw
+		clause.Condition = condition
+	}
+
+	thenKw, err := p.consumeKeyword(lexer.TThen)
+	if err != nil {
+		return nil, err
+	}
+	clause.ThenKeyword = *thenKw
+
+	// rows missing from the target can only be inserted, rows found in the
+	// target can only be updated or deleted
+	var action ast.MergeAction
+	if clause.Type == ast.MWNotMatchedByTarget {
+		if err := p.expectPeek(lexer.TInsert); err != nil {
+			return nil, err
+		}
+		action, err = p.parseMergeInsertAction()
+	} else if p.peekTokenIs(lexer.TUpdate) {
+		action, err = p.parseMergeUpdateAction()
+	} else if p.peekTokenIs(lexer.TDelete) {
+		deleteKw, _ := p.consumeKeyword(lexer.TDelete)
+		action = &ast.MergeDeleteAction{
+			DeleteKeyword: *deleteKw,
+			Span:          deleteKw.Span,
+		}
+	} else {
+		return nil, p.peekErrorMany([]lexer.TokenType{lexer.TUpdate, lexer.TDelete})
+	}
+	if err != nil {
+		return nil, err
+	}
+	clause.Action = action
+	clause.Span = ast.NewSpanFromLexerPosition(startPosition, action.GetSpan().EndPosition)
+
+	return clause, nil
+}
+
+func (p *Parser) parseMergeUpdateAction() (*ast.MergeUpdateAction, error) {
+	startPosition := p.peekToken.Start
+	updateKw, err := p.consumeKeyword(lexer.TUpdate)
+	if err != nil {
+		return nil, err
+	}
+	setKw, err := p.consumeKeyword(lexer.TSet)
+	if err != nil {
+		return nil, err
+	}
+	action := &ast.MergeUpdateAction{UpdateKeyword: *updateKw, SetKeyword: *setKw}
+
+	for {
+		assignment, err := p.parseAssignment()
+		if err != nil {
+			return nil, err
+		}
+		action.Assignments = append(action.Assignments, assignment)
+		action.Span = ast.NewSpanFromLexerPosition(startPosition, assignment.EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return action, nil
+}
+
+func (p *Parser) parseMergeInsertAction() (*ast.MergeInsertAction, error) {
+	startPosition := p.peekToken.Start
+	insertKw, err := p.consumeKeyword(lexer.TInsert)
+	if err != nil {
+		return nil, err
+	}
+	action := &ast.MergeInsertAction{InsertKeyword: *insertKw}
+
+	if p.peekTokenIs(lexer.TLeftParen) {
+		columns, err := p.parseColumnList()
+		if err != nil {
+			return nil, err
+		}
+		action.Columns = columns
+	}
+
+	if defaultKw := p.maybeKeyword(lexer.TDefault); defaultKw != nil {
+		valuesKw, err := p.consumeKeyword(lexer.TValues)
+		if err != nil {
+			return nil, err
+		}
+		action.DefaultValuesKeyword = &[2]ast.Keyword{*defaultKw, *valuesKw}
+		action.Span = ast.NewSpanFromLexerPosition(startPosition, valuesKw.EndPosition)
+		return action, nil
+	}
+
+	values, err := p.parseTableValueConstructor()
+	if err != nil {
+		return nil, err
+	}
+	if len(values.Rows) > 1 {
+		return nil, fmt.Errorf("'VALUES' in a 'MERGE' statement can only contain one row")
+	}
+	action.Values = values
+	action.Span = ast.NewSpanFromLexerPosition(startPosition, values.EndPosition)
+
+	return action, nil
+}
+
+func (p *Parser) parseOutputClause() (*ast.OutputClause, error) {
+	startPosition := p.peekToken.Start
+	outputKw, err := p.consumeKeyword(lexer.TOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := p.parseSelectItems()
+	if err != nil {
+		return nil, err
+	}
+	clause := &ast.OutputClause{
+		OutputKeyword: *outputKw,
+		Columns:       *columns,
+		Span:          ast.NewSpanFromLexerPosition(startPosition, columns.EndPosition),
+	}
+
+	if intoKw := p.maybeKeyword(lexer.TInto); intoKw != nil {
+		target, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		clause.IntoKeyword = intoKw
+		clause.IntoTarget = target
+		clause.Span = ast.NewSpanFromLexerPosition(startPosition, target.GetSpan().EndPosition)
+
+		if p.peekTokenIs(lexer.TLeftParen) {
+			intoColumns, err := p.parseColumnList()
+			if err != nil {
+				return nil, err
+			}
+			clause.IntoColumns = intoColumns
+			clause.Span = ast.NewSpanFromLexerPosition(startPosition, intoColumns.EndPosition)
+		}
+	}
+
+	return clause, nil
+}
+
+// parses a parenthesized list of column names `(a, b, c)`
+func (p *Parser) parseColumnList() (*ast.ExprExpressionList, error) {
+	leftParen, err := p.consumeToken(lexer.TLeftParen)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := p.parseExpressionList()
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	columns.Span = ast.NewSpanFromLexerPosition(leftParen.Start, rightParen.End)
+
+	return &columns, nil
+}
+
+var assignment_operators = map[lexer.TokenType]ast.AssignmentOperatorType{
+	lexer.TEqual:         ast.AssignmentOpEqual,
+	lexer.TPlusEqual:     ast.AssignmentOpPlusEqual,
+	lexer.TMinusEqual:    ast.AssignmentOpMinusEqual,
+	lexer.TMultiplyEqual: ast.AssignmentOpMultiplyEqual,
+	lexer.TDivideEqual:   ast.AssignmentOpDivideEqual,
+	lexer.TPercentEqual:  ast.AssignmentOpModEqual,
+	lexer.TAndEqual:      ast.AssignmentOpAndEqual,
+	lexer.TOrEqual:       ast.AssignmentOpOrEqual,
+	lexer.TCaretEqual:    ast.AssignmentOpXorEqual,
+}
+
+// parses `column = expr`, `column += expr` and `@variable = column = expr`
+func (p *Parser) parseAssignment() (*ast.ExprAssignmentOperator, error) {
+	left, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+
+	operator, ok := assignment_operators[p.peekToken.Type]
+	if !ok {
+		return nil, p.peekErrorString("assignment operator")
+	}
+	p.nextToken()
+
+	right, err := p.parseExpression(PrecedenceComparison)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isVariable := left.(*ast.ExprLocalVariable); isVariable && operator == ast.AssignmentOpEqual {
+		switch right.(type) {
+		case *ast.ExprIdentifier, *ast.ExprQuotedIdentifier, *ast.ExprCompoundIdentifier:
+			if token := p.maybeToken(lexer.TEqual); token != nil {
+				value, err := p.parseExpression(PrecedenceComparison)
+				if err != nil {
+					return nil, err
+				}
+				right = &ast.ExprAssignmentOperator{
+					Left:     right,
+					Operator: ast.AssignmentOpEqual,
+					Right:    value,
+					Span: ast.NewSpanFromLexerPosition(
+						right.GetSpan().StartPosition,
+						value.GetSpan().EndPosition,
+					),
+				}
+			}
+		}
+	}
+
+	return &ast.ExprAssignmentOperator{
+		Left:     left,
+		Operator: operator,
+		Right:    right,
+		Span: ast.NewSpanFromLexerPosition(
+			left.GetSpan().StartPosition,
+			right.GetSpan().EndPosition,
+		),
+	}, nil
+}
+
+func (p *Parser) parseExecuteStatement() (*ast.ExecuteStatement, error) {
+	startPosition := p.peekToken.Start
+	execKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TExec, lexer.TExecute})
+	if err != nil {
+		return nil, err
+	}
+	stmt := &ast.ExecuteStatement{ExecKeyword: *execKw}
+
+	// EXEC (@sql)
+	if token := p.maybeToken(lexer.TLeftParen); token != nil {
+		command, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Command = command
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+		return stmt, nil
+	}
+
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+	if variable, ok := name.(*ast.ExprLocalVariable); ok && p.peekTokenIs(lexer.TEqual) {
+		p.nextToken()
+		stmt.ReturnStatus = variable
+		name, err = p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+	}
+	stmt.Name = name
+	stmt.Span = ast.NewSpanFromLexerPosition(startPosition, name.GetSpan().EndPosition)
+
+	if p.expectFunctionArgsStart() != nil && !p.peekTokenIs(lexer.TDefault) {
+		return stmt, nil
+	}
+
+	for {
+		arg, err := p.parseExecuteArgument()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Arguments = append(stmt.Arguments, *arg)
+		stmt.Span = ast.NewSpanFromLexerPosition(startPosition, arg.EndPosition)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseExecuteArgument() (*ast.ExecuteArgument, error) {
+	arg := &ast.ExecuteArgument{}
+	startPosition := p.peekToken.Start
+
+	if p.peekTokenIs(lexer.TLocalVariable) {
+		variable := &ast.ExprLocalVariable{
+			Value: p.peekToken.Value,
+			Span:  ast.NewSpanFromToken(p.peekToken),
+		}
+		p.nextToken()
+
+		if token := p.maybeToken(lexer.TEqual); token != nil {
+			arg.Name = variable
+		} else {
+			value, err := p.parseInfixExpressions(variable, PrecedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			arg.Value = value
+		}
+	}
+
+	if arg.Value == nil {
+		if defaultKw := p.maybeKeyword(lexer.TDefault); defaultKw != nil {
+			arg.DefaultKeyword = defaultKw
+			arg.Span = ast.NewSpanFromLexerPosition(startPosition, defaultKw.EndPosition)
+		} else {
+			if err := p.expectFunctionArgsStart(); err != nil {
+				return nil, err
+			}
+			value, err := p.parseExpression(PrecedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			arg.Value = value
+		}
+	}
+	if arg.Value != nil {
+		arg.Span = ast.NewSpanFromLexerPosition(startPosition, arg.Value.GetSpan().EndPosition)
+	}
+
+	outputKw := p.maybeKeyword(lexer.TOutput)
+	if outputKw == nil {
+		outputKw = p.maybeIdentifierKeyword("out")
+	}
+	if outputKw != nil {
+		arg.OutputKeyword = outputKw
+		arg.Span = ast.NewSpanFromLexerPosition(startPosition, outputKw.EndPosition)
+	}
+
+	return arg, nil
+}
+
+func (p *Parser) parseTableValueConstructor() (*ast.TableValueConstructor, error) {
+	startPosition := p.peekToken.Start
+	valuesKw, err := p.consumeKeyword(lexer.TValues)
+	if err != nil {
+		return nil, err
+	}
+	values := &ast.TableValueConstructor{ValuesKeyword: *valuesKw}
+
+	for {
+		leftParen, err := p.consumeToken(lexer.TLeftParen)
+		if err != nil {
+			return nil, err
+		}
+
+		row := ast.ExprExpressionList{}
+		for {
+			if err := p.expectFunctionArgsStart(); err != nil {
+				return nil, err
+			}
+			expr, err := p.parseExpression(PrecedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			row.List = append(row.List, expr)
+
+			if token := p.maybeToken(lexer.TComma); token == nil {
+				break
+			}
+		}
+
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		row.Span = ast.NewSpanFromLexerPosition(leftParen.Start, rightParen.End)
+		values.Rows = append(values.Rows, row)
+		values.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+
+	return values, nil
+}
+
+func (p *Parser) parseObjectName() (ast.Expression, error) {
+	if err := p.expectPeekMany([]lexer.TokenType{
+		lexer.TIdentifier,
+		lexer.TQuotedIdentifier,
+		lexer.TLocalVariable,
+	}); err != nil {
+		return nil, err
+	}
+
+	var expr ast.Expression
+	switch p.peekToken.Type {
+	case lexer.TLocalVariable:
+		expr = &ast.ExprLocalVariable{
+			Value: p.peekToken.Value,
+			Span:  ast.NewSpanFromToken(p.peekToken),
+		}
+		p.nextToken()
+		return expr, nil
+	case lexer.TQuotedIdentifier:
+		expr = &ast.ExprQuotedIdentifier{
+			Value: p.peekToken.Value,
+			Span:  ast.NewSpanFromToken(p.peekToken),
+		}
+	case lexer.TIdentifier:
+		expr = &ast.ExprIdentifier{
+			Value: p.peekToken.Value,
+			Span:  ast.NewSpanFromToken(p.peekToken),
+		}
+	}
+	p.nextToken()
+
+	compound, err := p.parseCompoundIdentifier(expr)
+	if err != nil {
+		return nil, err
+	}
+	if compound != nil {
+		return compound, nil
+	}
+
+	return expr, nil
+}
+
+// insert, update, delete and merge only take a parenthesized TOP expression
+func (p *Parser) parseParenthesizedTopArg(topKw ast.Keyword) (*ast.TopArg, error) {
+	if !p.peekTokenIs(lexer.TLeftParen) {
+		return nil, p.peekErrorString("(")
+	}
+	return p.parseTopArg(topKw)
+}
+
+func (p *Parser) parseTopArg(topKw ast.Keyword) (*ast.TopArg, error) {
+	topArg := ast.TopArg{}
+	topArg.TopKeyword = topKw
+	startPosition := topKw.StartPosition
+	p.logger.Debug(p.peekToken)
+	if token := p.maybeToken(lexer.TLeftParen); token != nil {
+		expr, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		rightParen, err := p.consumeToken(lexer.TRightParen)
+		if err != nil {
+			return nil, err
+		}
+		topArg.Quantity = expr
+		topArg.Parenthesized = true
+		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+	} else {
+		numericLiteral, err := p.consumeToken(lexer.TNumericLiteral)
+		if err != nil {
+			return nil, err
+		}
+		expr := &ast.ExprNumberLiteral{
+			Value: numericLiteral.Value,
+			Span:  ast.NewSpanFromToken(*numericLiteral),
+		}
+		topArg.Quantity = expr
+		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, expr.EndPosition)
+	}
+
+	if kw := p.maybeKeyword(lexer.TPercent); kw != nil {
+		topArg.PercentKeyword = kw
+		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, kw.EndPosition)
+	}
+
+	if withKw := p.maybeKeyword(lexer.TWith); withKw != nil {
+		tiesKw, err := p.consumeKeyword(lexer.TTies)
+		if err != nil {
+			return nil, err
+		}
+		topArg.WithTiesKeyword = &[2]ast.Keyword{*withKw, *tiesKw}
+		topArg.Span = ast.NewSpanFromLexerPosition(startPosition, tiesKw.EndPosition)
+	}
+
+	return &topArg, nil
+}
+
+func (p *Parser) parseIntoClause() (*ast.IntoClause, error) {
+	startPosition := p.peekToken.Start
+	intoKw, err := p.consumeKeyword(lexer.TInto)
+	if err != nil {
+		return nil, err
+	}
+	// SELECT INTO creates a table, table variables have to be declared
+	if p.peekTokenIs(lexer.TLocalVariable) {
+		return nil, p.peekErrorString("table name")
+	}
+	target, err := p.parseObjectName()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.IntoClause{
+		IntoKeyword: *intoKw,
+		Target:      target,
+		Span:        ast.NewSpanFromLexerPosition(startPosition, target.GetSpan().EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseSelectBodyWithoutOrderBy() (ast.SelectBody, error) {
+	stmt := ast.SelectBody{}
+	startPositionSelectBody := p.peekToken.Start
+	kw, err := p.consumeKeyword(lexer.TSelect)
+	if err != nil {
+		return stmt, err
+	}
+	stmt.SelectKeyword = *kw
+	if kw := p.maybeKeyword(lexer.TDistinct); kw != nil {
+		stmt.DistinctKeyword = kw
+	}
+
+	// check for optional all keyword
+	if kw := p.maybeKeyword(lexer.TAll); kw != nil {
+		stmt.AllKeyword = kw
+	}
+
+	if kw := p.maybeKeyword(lexer.TTop); kw != nil {
+		topArg, err := p.parseTopArg(*kw)
+		if err != nil {
+			return stmt, err
+		}
+		stmt.Top = topArg
+	}
+
+	selectItems, err := p.parseSelectItems()
+	if err != nil {
+		return stmt, err
+	}
+	stmt.SelectItems = *selectItems
+	stmt.Span = ast.NewSpanFromLexerPosition(
+		startPositionSelectBody,
+		selectItems.EndPosition,
+	)
+
+	if p.peekTokenIs(lexer.TInto) {
+		intoClause, err := p.parseIntoClause()
+		if err != nil {
+			return stmt, err
+		}
+		stmt.IntoClause = intoClause
+		stmt.Span = ast.NewSpanFromLexerPosition(
+			startPositionSelectBody,
+			intoClause.EndPosition,
+		)
+	}
+
+	if p.peekTokenIs(lexer.TFrom) {
+		table, err := p.parseTableArg()
+		if err != nil {
+			return stmt, err
+		}
+		stmt.Table = table
+		stmt.Span = ast.NewSpanFromLexerPosition(
+			startPositionSelectBody,
+			table.EndPosition,
+		)
+	}
+
+	if p.peekTokenIs(lexer.TWhere) {
+		whereExpression, err := p.parseWhereExpression()
+		if err != nil {
+			return stmt, err
+		}
+		stmt.WhereClause = whereExpression
+		stmt.Span = ast.NewSpanFromLexerPosition(
+			startPositionSelectBody,
+			whereExpression.EndPosition,
+		)
+	}
+
+	if p.peekTokenIs(lexer.TGroup) {
+		groupByClause, err := p.parseGroupByClause()
+		if err != nil {
+			return stmt, err
+		}
+		stmt.GroupByClause = groupByClause
+		stmt.Span = ast.NewSpanFromLexerPosition(
+			startPositionSelectBody,
+			groupByClause.EndPosition,
+		)
+	}
+
+	if p.peekTokenIs(lexer.THaving) {
+		havingExpression, err := p.parseHavingExpression()
+		if err != nil {
+			return stmt, err
+		}
+		stmt.HavingClause = havingExpression
+		stmt.Span = ast.NewSpanFromLexerPosition(
+			startPositionSelectBody,
+			havingExpression.EndPosition,
+		)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseSelectSubquery() (ast.ExprSubquery, error) {
+	stmt := ast.ExprSubquery{}
+	startPositionSubquery := p.peekToken.Start
+	p.logger.Debug("parsing subquery")
+
+	query, err := p.parseQueryExpression()
+	if err != nil {
+		return stmt, err
+	}
+	stmt.Query = query
+	stmt.Span = ast.NewSpanFromLexerPosition(
+		startPositionSubquery,
+		query.GetSpan().EndPosition,
+	)
+
+	return stmt, nil
+}
+
+func (p *Parser) parseSelectItems() (*ast.SelectItems, error) {
+	selectItems := ast.SelectItems{}
+	startPositionSelectItems := p.peekToken.Start
+	p.logger.Debug(p.peekToken)
+	for {
+		err := p.expectSelectItemStart()
+		if err != nil {
+			return nil, err
+		}
+
+		var expr ast.Expression
+		if p.peekTokenIs(lexer.TLocalVariable) {
+			expr, err = p.parseSelectItemLocalVariable()
+		} else {
+			expr, err = p.parseExpression(PrecedenceLowest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if subquery, ok := expr.(*ast.ExprSubquery); ok {
+			// only a single select is checked
+			if v, ok := subquery.Query.(*ast.SelectBody); ok {
+				if len(v.SelectItems.Items) > 1 {
+					return nil, p.peekErrorString("Subquery must contain only one column")
+				}
+				if v.GroupByClause != nil && len(v.GroupByClause.Items) > 1 && v.DistinctKeyword != nil {
+					return nil, p.peekErrorString("The 'DISTINCT' keyword can't be used with subqueries that include 'GROUP BY'")
+				}
+				if v.OrderByClause != nil && len(v.OrderByClause.Expressions) > 1 && v.Top == nil {
+					return nil, p.peekErrorString("'ORDER BY' can only be specified when 'TOP' is also specified")
+				}
+			}
+			p.logger.Debugln("subquery in select item")
+		}
+
+		asKw := p.maybeKeyword(lexer.TAs)
+
+		if p.peekTokenIsAny([]lexer.TokenType{
+			lexer.TIdentifier,
+			lexer.TQuotedIdentifier,
+			lexer.TStringLiteral,
+		}) {
+			var alias ast.Expression
+			if p.peekTokenIs(lexer.TIdentifier) {
+				alias = &ast.ExprIdentifier{
+					Value: p.peekToken.Value,
+					Span:  ast.NewSpanFromToken(p.peekToken),
+				}
+			} else if p.peekTokenIs(lexer.TStringLiteral) {
+				alias = &ast.ExprStringLiteral{
+					Value: p.peekToken.Value,
+					Span:  ast.NewSpanFromToken(p.peekToken),
+				}
+			} else if p.peekTokenIs(lexer.TQuotedIdentifier) {
+				alias = &ast.ExprQuotedIdentifier{
+					Value: p.peekToken.Value,
+					Span:  ast.NewSpanFromToken(p.peekToken),
+				}
+			}
+
+			p.nextToken()
+
+			selectItem := &ast.ExprWithAlias{
+				Expression: expr,
+				AsKeyword:  asKw,
+				Alias:      alias,
+			}
+			selectItem.SetSpan(ast.Span{
+				StartPosition: expr.GetSpan().StartPosition,
+				EndPosition:   alias.GetSpan().EndPosition,
+			})
+			selectItems.Items = append(selectItems.Items, selectItem)
+		} else if asKw == nil {
+			selectItems.Items = append(selectItems.Items, expr)
+		} else {
+			return nil, fmt.Errorf("Missing alias after \"As\" keyword")
+		}
+
+		p.logger.Debug(p.peekToken)
+		if p.peekToken.Type != lexer.TComma {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	selectItems.SetSpan(ast.NewSpanFromLexerPosition(startPositionSelectItems, selectItems.Items[len(selectItems.Items)-1].GetSpan().EndPosition))
+	return &selectItems, nil
+}
+
+// parses `@variable = expr` in select items, otherwise the variable is the
+// start of a regular expression
+func (p *Parser) parseSelectItemLocalVariable() (ast.Expression, error) {
+	variable := &ast.ExprLocalVariable{
+		Value: p.peekToken.Value,
+		Span:  ast.NewSpanFromToken(p.peekToken),
+	}
+	p.nextToken()
+
+	if _, ok := assignment_operators[p.peekToken.Type]; ok {
+		return p.parseLocalVariableAssignment(variable)
+	}
+
+	return p.parseInfixExpressions(variable, PrecedenceLowest)
+}
+
+func (p *Parser) parseLocalVariableAssignment(variable *ast.ExprLocalVariable) (*ast.ExprAssignmentOperator, error) {
+	operator, ok := assignment_operators[p.peekToken.Type]
+	if !ok {
+		return nil, p.peekErrorString("assignment operator")
+	}
+	p.nextToken()
+
+	value, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ExprAssignmentOperator{
+		Left:     variable,
+		Operator: operator,
+		Right:    value,
+		Span: ast.NewSpanFromLexerPosition(
+			variable.StartPosition,
+			value.GetSpan().EndPosition,
+		),
+	}, nil
+}
+
+func (p *Parser) parseTableArg() (*ast.TableArg, error) {
+	fromKeyword, err := p.consumeKeyword(lexer.TFrom)
+	if err != nil {
+		p.logger.Debug("from err")
+		return nil, err
+	}
+	startPosition := fromKeyword.StartPosition
+	p.logger.Debug("parsing table arg")
+	p.logger.Debug(p.peekToken)
+
+	tableSource, err := p.parseTableSource()
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.Debugf("peek token: %s", p.peekToken.Value)
+	if !p.peekTokenIsAny([]lexer.TokenType{
+		lexer.TInner,
+		lexer.TLeft,
+		lexer.TRight,
+		lexer.TFull,
+		lexer.TCross,
+		lexer.TOuter,
+	}) {
+		return &ast.TableArg{
+			FromKeyword: *fromKeyword,
+			Table:       tableSource,
+			Span:        ast.NewSpanFromLexerPosition(startPosition, tableSource.EndPosition),
+		}, nil
+	}
+
+	joins, err := p.parseJoins()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.TableArg{
+		FromKeyword: *fromKeyword,
+		Table:       tableSource,
+		Joins:       joins,
+		Span:        ast.NewSpanFromLexerPosition(startPosition, joins[len(joins)-1].EndPosition),
+	}, nil
+}
+
+func (p *Parser) parseTableSource() (*ast.TableSource, error) {
+	startPosition := p.peekToken.Start
+	err := p.expectTableSourceStart()
+	if err != nil {
+		return nil, err
+	}
+
+	var source ast.Expression
+	if p.peekTokenIs(lexer.TLeftParen) && p.peekSecondTokenIs(lexer.TValues) {
+		source, err = p.parseValuesTable()
+	} else if p.peekTokenIs(lexer.TIdentifier) && p.peekSecondTokenIs(lexer.TLeftParen) &&
+		(strings.EqualFold(p.peekToken.Value, "openjson") || strings.EqualFold(p.peekToken.Value, "openxml")) {
+		source, err = p.parseRowsetFunction()
+	} else {
+		source, err = p.parseExpression(PrecedenceLowest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.logger.Debugf("source %v", source)
+
+	var tableSourceType ast.TableSourceType
+	switch source.(type) {
+	case *ast.ExprIdentifier, *ast.ExprCompoundIdentifier, *ast.ExprLocalVariable:
+		tableSourceType = ast.TSTTable
+	case *ast.ExprFunctionCall, *ast.ExprRowsetFunction:
+		tableSourceType = ast.TSTTableValuedFunction
+	case *ast.ExprSubquery, *ast.ExprValuesTable:
+		tableSourceType = ast.TSTDerived
+	default:
+		return nil, p.peekErrorString("Table Name or Function or Subquery")
+	}
+
+	source, err = p.parseTableAlias(source)
+	if err != nil {
+		return nil, err
+	}
+
+	// derived tables can name their columns after the alias `AS v(id, name)`
+	var columns *ast.ExprExpressionList
+	if _, ok := source.(*ast.ExprWithAlias); ok && tableSourceType == ast.TSTDerived && p.peekTokenIs(lexer.TLeftParen) {
+		columns, err = p.parseColumnList()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for p.peekTokenIsAny([]lexer.TokenType{lexer.TPivot, lexer.TUnpivot}) {
+		pivot, err := p.parsePivot(source)
+		if err != nil {
+			return nil, err
+		}
+		if pivot.PivotKeyword.Type == ast.KPivot {
+			tableSourceType = ast.TSTPivot
+		} else {
+			tableSourceType = ast.TSTUnpivot
+		}
+
+		source, err = p.parseTableAlias(pivot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tableSource := &ast.TableSource{
+		Type:    tableSourceType,
+		Source:  source,
+		Columns: columns,
+		Span:    ast.NewSpanFromLexerPosition(startPosition, source.GetSpan().EndPosition),
+	}
+	if columns != nil {
+		tableSource.Span = ast.NewSpanFromLexerPosition(startPosition, columns.EndPosition)
+	}
+
+	if err := p.parseTableHints(tableSource); err != nil {
+		return nil, err
+	}
+
+	return tableSource, nil
+}
+
+// parses the `WITH (NOLOCK, ...)` after a table into the table source
+func (p *Parser) parseTableHints(tableSource *ast.TableSource) error {
+	// a WITH that is not followed by a parenthesis starts the next statement
+	if !p.peekTokenIs(lexer.TWith) || !p.peekSecondTokenIs(lexer.TLeftParen) {
+		return nil
+	}
+	tableSource.WithKeyword, _ = p.consumeKeyword(lexer.TWith)
+	p.nextToken()
+	for {
+		hint, err := p.parseTableHint()
+		if err != nil {
+			return err
+		}
+		tableSource.Hints = append(tableSource.Hints, *hint)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return err
+	}
+	tableSource.Span = ast.NewSpanFromLexerPosition(tableSource.StartPosition, rightParen.End)
+
+	return nil
+}
+
+// wraps the target of an insert, update, delete or merge in a table source
+// when it has table hints
+func (p *Parser) parseTargetTableHints(target ast.Expression) (ast.Expression, error) {
+	if !p.peekTokenIs(lexer.TWith) || !p.peekSecondTokenIs(lexer.TLeftParen) {
+		return target, nil
+	}
+	tableSource := &ast.TableSource{
+		Type:   ast.TSTTable,
+		Source: target,
+		Span:   target.GetSpan(),
+	}
+	if err := p.parseTableHints(tableSource); err != nil {
+		return nil, err
+	}
+
+	return tableSource, nil
+}
+
+func (p *Parser) parseValuesTable() (*ast.ExprValuesTable, error) {
+	leftParen, err := p.consumeToken(lexer.TLeftParen)
+	if err != nil {
+		return nil, err
+	}
+	values, err := p.parseTableValueConstructor()
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &ast.ExprValuesTable{TableValueConstructor: *values}
+	table.Span = ast.NewSpanFromLexerPosition(leftParen.Start, rightParen.End)
+	return table, nil
+}
+
+// parses OPENJSON and OPENXML along with the WITH that describes their columns
+func (p *Parser) parseRowsetFunction() (*ast.ExprRowsetFunction, error) {
+	startPosition := p.peekToken.Start
+	functionKw, err := ast.NewKeywordFromTokenNew(p.peekToken)
+	if err != nil {
+		return nil, err
+	}
+	p.nextToken()
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	args, err := p.parseFunctionArgs()
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	function := &ast.ExprRowsetFunction{
+		FunctionKeyword: *functionKw,
+		Args:            *args,
+		Span:            ast.NewSpanFromLexerPosition(startPosition, rightParen.End),
+	}
+
+	function.WithKeyword = p.maybeKeyword(lexer.TWith)
+	if function.WithKeyword == nil {
+		return function, nil
+	}
+
+	if !p.peekTokenIs(lexer.TLeftParen) {
+		if function.FunctionKeyword.Type != ast.KOpenxml {
+			return nil, p.peekErrorString("(")
+		}
+		table, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
+		}
+		function.SchemaTable = table
+		function.Span = ast.NewSpanFromLexerPosition(startPosition, table.GetSpan().EndPosition)
+		return function, nil
+	}
+
+	p.nextToken()
+	for {
+		column, err := p.parseRowsetColumn()
+		if err != nil {
+			return nil, err
+		}
+		function.Columns = append(function.Columns, *column)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+	rightParen, err = p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	function.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+
+	return function, nil
+}
+
+func (p *Parser) parseRowsetColumn() (*ast.RowsetColumn, error) {
+	startPosition := p.peekToken.Start
+	var name ast.Expression
+	if token := p.maybeToken(lexer.TIdentifier); token != nil {
+		name = &ast.ExprIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
+		name = &ast.ExprQuotedIdentifier{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else {
+		return nil, p.peekErrorMany([]lexer.TokenType{lexer.TIdentifier, lexer.TQuotedIdentifier})
 	}
 
-	if p.peekTokenIs(lexer.THaving) {
-		havingExpression, err := p.parseHavingExpression()
-		if err != nil {
-			return stmt, err
-		}
-		stmt.HavingClause = havingExpression
-		stmt.Span = ast.NewSpanFromLexerPosition(
-			startPositionSelectBody,
-			havingExpression.EndPosition,
-		)
+	dataType, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+	column := &ast.RowsetColumn{
+		Name:     name,
+		DataType: *dataType,
+		Span:     ast.NewSpanFromLexerPosition(startPosition, dataType.EndPosition),
 	}
 
-	if p.peekTokenIs(lexer.TOrder) {
-		orderByClause, err := p.parseOrderByClause()
-		if err != nil {
-			return stmt, err
+	if token := p.maybeToken(lexer.TStringLiteral); token != nil {
+		column.Path = &ast.ExprStringLiteral{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+		column.Span = ast.NewSpanFromLexerPosition(startPosition, token.End)
+	}
+
+	// AS JSON keeps a nested object or array as json text
+	if asKw := p.maybeKeyword(lexer.TAs); asKw != nil {
+		jsonKw := p.maybeIdentifierKeyword("json")
+		if jsonKw == nil {
+			return nil, p.peekErrorString("Json")
 		}
-		stmt.OrderByClause = orderByClause
-		stmt.Span = ast.NewSpanFromLexerPosition(
-			startPositionSelectBody,
-			orderByClause.EndPosition,
-		)
+		column.AsJsonKeyword = &[2]ast.Keyword{*asKw, *jsonKw}
+		column.Span = ast.NewSpanFromLexerPosition(startPosition, jsonKw.EndPosition)
 	}
 
-	return stmt, nil
+	return column, nil
 }
 
-func (p *Parser) parseSelectSubquery() (ast.ExprSubquery, error) {
-	stmt := ast.ExprSubquery{}
-	startPositionSubquery := p.peekToken.Start
-	p.logger.Debug("parsing subquery")
+var table_hints = []string{
+	"nolock",
+	"holdlock",
+	"forcescan",
+	"readpast",
+	"readcommitted",
+	"readcommittedlock",
+	"readuncommitted",
+	"repeatableread",
+	"serializable",
+	"rowlock",
+	"paglock",
+	"tablock",
+	"tablockx",
+	"updlock",
+	"xlock",
+	"nowait",
+	"noexpand",
+	"keepidentity",
+	"keepdefaults",
+	"ignore_constraints",
+	"ignore_triggers",
+}
 
-	selectBody, err := p.parseSelectBody()
-	if err != nil {
-		return stmt, err
+func (p *Parser) parseTableHint() (*ast.TableHint, error) {
+	for _, hint := range table_hints {
+		if kw := p.maybeIdentifierKeyword(hint); kw != nil {
+			return &ast.TableHint{HintKeyword: *kw, Span: kw.Span}, nil
+		}
+	}
+	if kw := p.maybeKeyword(lexer.TSnapshot); kw != nil {
+		return &ast.TableHint{HintKeyword: *kw, Span: kw.Span}, nil
+	}
+	if kw := p.maybeIdentifierKeyword("forceseek"); kw != nil {
+		return p.parseForceseekHint(*kw)
 	}
-	stmt.SelectBody = selectBody
-	stmt.Span = ast.NewSpanFromLexerPosition(
-		startPositionSubquery,
-		selectBody.EndPosition,
-	)
 
-	return stmt, nil
-}
+	indexKw, err := p.consumeKeyword(lexer.TIndex)
+	if err != nil {
+		return nil, p.peekErrorString("table hint")
+	}
+	hint := &ast.TableHint{HintKeyword: *indexKw}
 
-func (p *Parser) parseSelectItems() (*ast.SelectItems, error) {
-	selectItems := ast.SelectItems{}
-	startPositionSelectItems := p.peekToken.Start
-	p.logger.Debug(p.peekToken)
-	for {
-		err := p.expectSelectItemStart()
+	// INDEX = IX_Quotes is the same as INDEX(IX_Quotes)
+	if token := p.maybeToken(lexer.TEqual); token != nil {
+		index, err := p.parseExpression(PrecedenceLowest)
 		if err != nil {
 			return nil, err
 		}
+		hint.Indexes = []ast.Expression{index}
+		hint.Span = ast.NewSpanFromLexerPosition(indexKw.StartPosition, index.GetSpan().EndPosition)
+		return hint, nil
+	}
 
-		expr, err := p.parseExpression(PrecedenceLowest)
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	for {
+		index, err := p.parseExpression(PrecedenceLowest)
 		if err != nil {
 			return nil, err
 		}
-		switch v := expr.(type) {
-		case *ast.ExprSubquery:
-			if len(v.SelectItems.Items) > 1 {
-				return nil, p.peekErrorString("Subquery must contain only one column")
-			}
-			if v.GroupByClause != nil && len(v.GroupByClause.Items) > 1 && v.DistinctKeyword != nil {
-				return nil, p.peekErrorString("The 'DISTINCT' keyword can't be used with subqueries that include 'GROUP BY'")
-			}
-			if v.OrderByClause != nil && len(v.OrderByClause.Expressions) > 1 && v.Top == nil {
-				return nil, p.peekErrorString("'ORDER BY' can only be specified when 'TOP' is also specified")
-			}
-			p.logger.Debugln("subquery in select item")
+		hint.Indexes = append(hint.Indexes, index)
+
+		if token := p.maybeToken(lexer.TComma); token == nil {
 			break
 		}
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	hint.Span = ast.NewSpanFromLexerPosition(indexKw.StartPosition, rightParen.End)
 
-		asKw := p.maybeKeyword(lexer.TAs)
-
-		if p.peekTokenIsAny([]lexer.TokenType{
-			lexer.TIdentifier,
-			lexer.TQuotedIdentifier,
-			lexer.TStringLiteral,
-		}) {
-			var alias ast.Expression
-			if p.peekTokenIs(lexer.TIdentifier) {
-				alias = &ast.ExprIdentifier{
-					Value: p.peekToken.Value,
-					Span:  ast.NewSpanFromToken(p.peekToken),
-				}
-			} else if p.peekTokenIs(lexer.TStringLiteral) {
-				alias = &ast.ExprStringLiteral{
-					Value: p.peekToken.Value,
-					Span:  ast.NewSpanFromToken(p.peekToken),
-				}
-			} else if p.peekTokenIs(lexer.TQuotedIdentifier) {
-				alias = &ast.ExprQuotedIdentifier{
-					Value: p.peekToken.Value,
-					Span:  ast.NewSpanFromToken(p.peekToken),
-				}
-			}
+	return hint, nil
+}
 
-			p.nextToken()
+// parses the optional `(IX_Quotes (Symbol, TradeDate))` of a FORCESEEK
+func (p *Parser) parseForceseekHint(forceseekKw ast.Keyword) (*ast.TableHint, error) {
+	hint := &ast.TableHint{HintKeyword: forceseekKw, Span: forceseekKw.Span}
+	if p.maybeToken(lexer.TLeftParen) == nil {
+		return hint, nil
+	}
 
-			selectItem := &ast.ExprWithAlias{
-				Expression: expr,
-				AsKeyword:  asKw,
-				Alias:      alias,
-			}
-			selectItem.SetSpan(ast.Span{
-				StartPosition: expr.GetSpan().StartPosition,
-				EndPosition:   alias.GetSpan().EndPosition,
-			})
-			selectItems.Items = append(selectItems.Items, selectItem)
-		} else if asKw == nil {
-			selectItems.Items = append(selectItems.Items, expr)
-		} else {
-			return nil, fmt.Errorf("Missing alias after \"As\" keyword")
+	var index ast.Expression
+	if token := p.maybeToken(lexer.TNumericLiteral); token != nil {
+		index = &ast.ExprNumberLiteral{Value: token.Value, Span: ast.NewSpanFromToken(*token)}
+	} else {
+		name, err := p.parseObjectName()
+		if err != nil {
+			return nil, err
 		}
+		index = name
+	}
+	hint.Indexes = []ast.Expression{index}
 
-		p.logger.Debug(p.peekToken)
-		if p.peekToken.Type != lexer.TComma {
-			break
-		}
+	columns, err := p.parseColumnList()
+	if err != nil {
+		return nil, err
+	}
+	hint.Columns = columns
 
-		p.nextToken()
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
 	}
+	hint.Span = ast.NewSpanFromLexerPosition(forceseekKw.StartPosition, rightParen.End)
 
-	selectItems.SetSpan(ast.NewSpanFromLexerPosition(startPositionSelectItems, selectItems.Items[len(selectItems.Items)-1].GetSpan().EndPosition))
-	return &selectItems, nil
+	return hint, nil
 }
 
-func (p *Parser) parseTableArg() (*ast.TableArg, error) {
-	fromKeyword, err := p.consumeKeyword(lexer.TFrom)
+// parses the PIVOT or UNPIVOT operator applied to a table source
+func (p *Parser) parsePivot(source ast.Expression) (*ast.ExprPivot, error) {
+	pivotKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TPivot, lexer.TUnpivot})
 	if err != nil {
-		p.logger.Debug("from err")
 		return nil, err
 	}
-	startPosition := fromKeyword.StartPosition
-	p.logger.Debug("parsing table arg")
-	p.logger.Debug(p.peekToken)
-
-	tableSource, err := p.parseTableSource()
-	if err != nil {
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
 		return nil, err
 	}
 
-	p.logger.Debugf("peek token: %s", p.peekToken.Value)
-	if !p.peekTokenIsAny([]lexer.TokenType{
-		lexer.TInner,
-		lexer.TLeft,
-		lexer.TRight,
-		lexer.TFull,
-	}) {
-		return &ast.TableArg{
-			FromKeyword: *fromKeyword,
-			Table:       tableSource,
-			Span:        ast.NewSpanFromLexerPosition(startPosition, tableSource.EndPosition),
-		}, nil
+	var aggregate ast.Expression
+	if pivotKw.Type == ast.KPivot {
+		aggregate, err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := aggregate.(*ast.ExprFunctionCall); !ok {
+			return nil, p.peekErrorString("aggregate function")
+		}
+	} else {
+		aggregate, err = p.parsePivotColumn()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	joins, err := p.parseJoins()
+	forKw, err := p.consumeKeyword(lexer.TFor)
 	if err != nil {
 		return nil, err
 	}
-
-	return &ast.TableArg{
-		FromKeyword: *fromKeyword,
-		Table:       tableSource,
-		Joins:       joins,
-		Span:        ast.NewSpanFromLexerPosition(startPosition, joins[len(joins)-1].EndPosition),
-	}, nil
-}
-
-func (p *Parser) parseTableSource() (*ast.TableSource, error) {
-	startPosition := p.peekToken.Start
-	err := p.expectTableSourceStart()
+	column, err := p.parsePivotColumn()
 	if err != nil {
 		return nil, err
 	}
-
-	source, err := p.parseExpression(PrecedenceLowest)
+	inKw, err := p.consumeKeyword(lexer.TIn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	values, err := p.parseObjectNames()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TRightParen); err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
 	if err != nil {
 		return nil, err
 	}
-	p.logger.Debugf("source %v", source)
 
-	var tableSourceType ast.TableSourceType
-	switch source.(type) {
-	case *ast.ExprIdentifier, *ast.ExprCompoundIdentifier, *ast.ExprLocalVariable:
-		tableSourceType = ast.TSTTable
-	case *ast.ExprFunctionCall:
-		tableSourceType = ast.TSTTableValuedFunction
-	case *ast.ExprSubquery:
-		tableSourceType = ast.TSTDerived
-	default:
-		return nil, p.peekErrorString("Table Name or Function or Subquery")
+	return &ast.ExprPivot{
+		Source:       source,
+		PivotKeyword: *pivotKw,
+		Aggregate:    aggregate,
+		ForKeyword:   *forKw,
+		Column:       column,
+		InKeyword:    *inKw,
+		Values:       values,
+		Span:         ast.NewSpanFromLexerPosition(source.GetSpan().StartPosition, rightParen.End),
+	}, nil
+}
+
+// keywords that are not reserved in t-sql and are common column names
+var non_reserved_column_keywords = append([]lexer.TokenType{
+	lexer.TDate,
+	lexer.TTime,
+	lexer.TValue,
+	lexer.TDayofweek,
+}, date_part_keywords...)
+
+// parses a pivot column, which can be a non reserved keyword like `month`
+func (p *Parser) parsePivotColumn() (ast.Expression, error) {
+	if !p.peekTokenIsAny(non_reserved_column_keywords) {
+		return p.parseObjectName()
 	}
+	column := &ast.ExprIdentifier{
+		Value: p.peekToken.Value,
+		Span:  ast.NewSpanFromToken(p.peekToken),
+	}
+	p.nextToken()
+
+	return column, nil
+}
 
-	// check table alias
-	if p.peekTokenIsAny([]lexer.TokenType{
+// wraps the table in an alias when it is followed by `[AS] alias`
+func (p *Parser) parseTableAlias(table ast.Expression) (ast.Expression, error) {
+	asKw := p.maybeKeyword(lexer.TAs)
+	if asKw == nil && !p.peekTokenIsAny([]lexer.TokenType{
 		lexer.TIdentifier,
 		lexer.TQuotedIdentifier,
 	}) {
-		var alias ast.Expression
-		if token := p.maybeToken(lexer.TIdentifier); token != nil {
-			alias = &ast.ExprIdentifier{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			}
-		} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
-			alias = &ast.ExprQuotedIdentifier{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			}
+		return table, nil
+	}
+
+	var alias ast.Expression
+	if token := p.maybeToken(lexer.TIdentifier); token != nil {
+		alias = &ast.ExprIdentifier{
+			Value: token.Value,
+			Span:  ast.NewSpanFromToken(*token),
 		}
-		source = &ast.ExprWithAlias{
-			Span:       ast.NewSpanFromLexerPosition(startPosition, alias.GetSpan().EndPosition),
-			Expression: source,
-			Alias:      alias,
+	} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
+		alias = &ast.ExprQuotedIdentifier{
+			Value: token.Value,
+			Span:  ast.NewSpanFromToken(*token),
 		}
+	} else {
+		return nil, fmt.Errorf("Missing alias after \"As\" keyword")
 	}
 
-	return &ast.TableSource{
-		Type:   tableSourceType,
-		Source: source,
-		Span:   ast.NewSpanFromLexerPosition(startPosition, source.GetSpan().EndPosition),
+	return &ast.ExprWithAlias{
+		Span:       ast.NewSpanFromLexerPosition(table.GetSpan().StartPosition, alias.GetSpan().EndPosition),
+		Expression: table,
+		AsKeyword:  asKw,
+		Alias:      alias,
 	}, nil
 }
 
@@ -493,6 +2862,30 @@ func (p *Parser) parseJoins() ([]ast.Join, error) {
 			} else {
 				joinType = ast.JTFull
 			}
+		} else if p.peekTokenIsAny([]lexer.TokenType{lexer.TCross, lexer.TOuter}) {
+			kw, _ := p.consumeKeywordAny([]lexer.TokenType{lexer.TCross, lexer.TOuter})
+			// APPLY is lexed as an identifier since it is not reserved
+			applyKw := p.maybeIdentifierKeyword("apply")
+			if applyKw == nil {
+				return nil, p.peekErrorString("APPLY")
+			}
+			if kw.Type == ast.KCross {
+				joinType = ast.JTCrossApply
+			} else {
+				joinType = ast.JTOuterApply
+			}
+
+			tableSource, err := p.parseTableSource()
+			if err != nil {
+				return nil, err
+			}
+			joins = append(joins, ast.Join{
+				JoinTypeKeyword: []ast.Keyword{*kw, *applyKw},
+				Type:            joinType,
+				Table:           tableSource,
+				Span:            ast.NewSpanFromLexerPosition(startPosition, tableSource.EndPosition),
+			})
+			continue
 		} else {
 			break
 		}
@@ -589,28 +2982,106 @@ func (p *Parser) parseGroupByClause() (*ast.GroupByClause, error) {
 	p.logger.Debug("parsing group by clause")
 
 	for {
-		err := p.expectGroupByStart()
+		expr, err := p.parseGroupByItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, expr)
+
+		if p.peekToken.Type != lexer.TComma {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	groupByClause.Items = items
+	groupByClause.SetSpan(ast.NewSpanFromLexerPosition(startPosition, items[len(items)-1].GetSpan().EndPosition))
+	return &groupByClause, nil
+}
+
+// parses a column, a parenthesized set of columns or a ROLLUP, CUBE or GROUPING SETS
+func (p *Parser) parseGroupByItem() (ast.Expression, error) {
+	startPosition := p.peekToken.Start
+	grouping := &ast.ExprGrouping{}
+	if kw := p.maybeKeyword(lexer.TGrouping); kw != nil {
+		setsKw := p.maybeIdentifierKeyword("sets")
+		if setsKw == nil {
+			return nil, p.peekErrorString("Sets")
+		}
+		grouping.Type = ast.GTGroupingSets
+		grouping.Keywords = []ast.Keyword{*kw, *setsKw}
+	} else if p.peekTokenIs(lexer.TIdentifier) && p.peekSecondTokenIs(lexer.TLeftParen) {
+		if kw := p.maybeIdentifierKeyword("rollup"); kw != nil {
+			grouping.Type = ast.GTRollup
+			grouping.Keywords = []ast.Keyword{*kw}
+		} else if kw := p.maybeIdentifierKeyword("cube"); kw != nil {
+			grouping.Type = ast.GTCube
+			grouping.Keywords = []ast.Keyword{*kw}
+		}
+	}
+
+	if grouping.Keywords == nil {
+		if p.peekTokenIs(lexer.TLeftParen) {
+			return p.parseGroupingSet()
+		}
+		if err := p.expectGroupByStart(); err != nil {
+			return nil, err
+		}
+		return p.parseExpression(PrecedenceLowest)
+	}
+
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	for {
+		set, err := p.parseGroupByItem()
 		if err != nil {
 			return nil, err
 		}
+		grouping.Sets = append(grouping.Sets, set)
 
+		if token := p.maybeToken(lexer.TComma); token == nil {
+			break
+		}
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	grouping.Span = ast.NewSpanFromLexerPosition(startPosition, rightParen.End)
+
+	return grouping, nil
+}
+
+func (p *Parser) parseGroupingSet() (*ast.ExprGroupingSet, error) {
+	leftParen, err := p.consumeToken(lexer.TLeftParen)
+	if err != nil {
+		return nil, err
+	}
+	set := &ast.ExprGroupingSet{}
+
+	for !p.peekTokenIs(lexer.TRightParen) {
+		if err := p.expectGroupByStart(); err != nil {
+			return nil, err
+		}
 		expr, err := p.parseExpression(PrecedenceLowest)
 		if err != nil {
 			return nil, err
 		}
-		items = append(items, expr)
+		set.Items = append(set.Items, expr)
 
-		if p.peekToken.Type != lexer.TComma {
+		if token := p.maybeToken(lexer.TComma); token == nil {
 			break
 		}
-
-		p.nextToken()
 	}
-	p.nextToken()
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+	set.Span = ast.NewSpanFromLexerPosition(leftParen.Start, rightParen.End)
 
-	groupByClause.Items = items
-	groupByClause.SetSpan(ast.NewSpanFromLexerPosition(startPosition, items[len(items)-1].GetSpan().EndPosition))
-	return &groupByClause, nil
+	return set, nil
 }
 
 func (p *Parser) parseHavingExpression() (*ast.HavingClause, error) {
@@ -692,13 +3163,8 @@ func (p *Parser) parseOrderByArgs() ([]ast.OrderByArg, error) {
 
 	for {
 		startPosition := p.peekToken.Start
-		err := p.expectPeekMany([]lexer.TokenType{
-			lexer.TIdentifier,
-			lexer.TNumericLiteral,
-			lexer.TLocalVariable,
-			lexer.TQuotedIdentifier,
-		})
-		if err != nil {
+		// order by items start like select items
+		if err := p.expectSelectItemStart(); err != nil {
 			return items, err
 		}
 
@@ -1156,6 +3622,14 @@ func (p *Parser) parseNumericSize() (*ast.NumericSize, error) {
 	}, nil
 }
 
+var string_data_types = map[lexer.TokenType]ast.DataTypeKind{
+	lexer.TVarchar:   ast.DTVarchar,
+	lexer.TChar:      ast.DTChar,
+	lexer.TNchar:     ast.DTNchar,
+	lexer.TNvarchar:  ast.DTNvarchar,
+	lexer.TVarbinary: ast.DTVarbinary,
+}
+
 func (p *Parser) parseDataType() (*ast.DataType, error) {
 	startPosition := p.peekToken.Start
 	p.logger.Debugf("peek token: %s", p.peekToken.Value)
@@ -1233,8 +3707,8 @@ func (p *Parser) parseDataType() (*ast.DataType, error) {
 		}
 		dataType.DecimalNumericSize = numericSize
 		dataType.Span = ast.NewSpanFromLexerPosition(startPosition, numericSize.EndPosition)
-	case lexer.TVarchar:
-		dataType.Kind = ast.DTVarchar
+	case lexer.TVarchar, lexer.TChar, lexer.TNchar, lexer.TNvarchar, lexer.TVarbinary:
+		dataType.Kind = string_data_types[dataTypeToken.Type]
 		if !p.peekTokenIs(lexer.TLeftParen) {
 			break
 		}
@@ -1242,16 +3716,20 @@ func (p *Parser) parseDataType() (*ast.DataType, error) {
 		if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
 			return nil, err
 		}
-		numericLiteral, err := p.consumeToken(lexer.TNumericLiteral)
-		if err != nil {
-			return nil, err
-		}
-		size, err := strconv.ParseUint(numericLiteral.Value, 10, 32)
-		if err != nil {
-			return nil, p.peekErrorString("could not convert numeric literal to uint32")
+		if maxToken := p.maybeToken(lexer.TMax); maxToken != nil {
+			dataType.MaxLength = true
+		} else {
+			numericLiteral, err := p.consumeToken(lexer.TNumericLiteral)
+			if err != nil {
+				return nil, err
+			}
+			size, err := strconv.ParseUint(numericLiteral.Value, 10, 32)
+			if err != nil {
+				return nil, p.peekErrorString("could not convert numeric literal to uint32")
+			}
+			size32 := uint32(size)
+			dataType.VarcharLength = &size32
 		}
-		size32 := uint32(size)
-		dataType.FloatPrecision = &size32
 		rightParen, err := p.consumeToken(lexer.TRightParen)
 		if err != nil {
 			return nil, err
@@ -1266,9 +3744,13 @@ func (p *Parser) parseDataType() (*ast.DataType, error) {
 
 func (p *Parser) parseCast() (*ast.ExprCast, error) {
 	startPosition := p.peekToken.Start
-	castKw, err := p.consumeKeyword(lexer.TCast)
-	if err != nil {
-		return nil, err
+	castKw := p.maybeIdentifierKeyword("try_cast")
+	if castKw == nil {
+		kw, err := p.consumeKeyword(lexer.TCast)
+		if err != nil {
+			return nil, err
+		}
+		castKw = kw
 	}
 	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
 		return nil, err
@@ -1306,6 +3788,188 @@ func (p *Parser) parseCast() (*ast.ExprCast, error) {
 	}, nil
 }
 
+func (p *Parser) parseConvert() (*ast.ExprConvert, error) {
+	startPosition := p.peekToken.Start
+	convertKw, err := p.consumeKeywordAny([]lexer.TokenType{lexer.TConvert, lexer.TTryConvert})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	p.logger.Debug("parsing convert expression")
+
+	dt, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TComma); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	var style ast.Expression
+	if p.maybeToken(lexer.TComma) != nil {
+		style, err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ExprConvert{
+		ConvertKeyword: *convertKw,
+		DataType:       *dt,
+		Expression:     expr,
+		Style:          style,
+		Span:           ast.NewSpanFromLexerPosition(startPosition, rightParen.End),
+	}, nil
+}
+
+func (p *Parser) parseParse() (*ast.ExprParse, error) {
+	startPosition := p.peekToken.Start
+	parseKw := p.maybeIdentifierKeyword("parse")
+	if parseKw == nil {
+		parseKw = p.maybeIdentifierKeyword("try_parse")
+	}
+	if parseKw == nil {
+		return nil, p.peekErrorString("expected PARSE or TRY_PARSE")
+	}
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	p.logger.Debug("parsing parse expression")
+
+	expr, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	asKw, err := p.consumeKeyword(lexer.TAs)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := p.parseDataType()
+	if err != nil {
+		return nil, err
+	}
+
+	var culture ast.Expression
+	usingKw := p.maybeKeyword(lexer.TUsing)
+	if usingKw != nil {
+		culture, err = p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ExprParse{
+		ParseKeyword: *parseKw,
+		Expression:   expr,
+		AsKeyword:    *asKw,
+		DataType:     *dt,
+		UsingKeyword: usingKw,
+		Culture:      culture,
+		Span:         ast.NewSpanFromLexerPosition(startPosition, rightParen.End),
+	}, nil
+}
+
+func (p *Parser) parseCase() (*ast.ExprCase, error) {
+	startPosition := p.peekToken.Start
+	caseKw, err := p.consumeKeyword(lexer.TCase)
+	if err != nil {
+		return nil, err
+	}
+	p.logger.Debug("parsing case expression")
+
+	caseExpr := ast.ExprCase{CaseKeyword: *caseKw}
+
+	// simple case expressions have an input expression before the first when
+	if !p.peekTokenIs(lexer.TWhen) {
+		input, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		caseExpr.InputExpression = input
+	}
+
+	for p.peekTokenIs(lexer.TWhen) {
+		whenClause, err := p.parseWhenClause()
+		if err != nil {
+			return nil, err
+		}
+		caseExpr.WhenClauses = append(caseExpr.WhenClauses, *whenClause)
+	}
+
+	if len(caseExpr.WhenClauses) == 0 {
+		return nil, p.peekErrorString("'WHEN' after 'CASE' keyword")
+	}
+
+	if elseKw := p.maybeKeyword(lexer.TElse); elseKw != nil {
+		result, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
+		}
+		caseExpr.ElseClause = &ast.ElseClause{
+			ElseKeyword: *elseKw,
+			Result:      result,
+			Span:        ast.NewSpanFromLexerPosition(elseKw.StartPosition, result.GetSpan().EndPosition),
+		}
+	}
+
+	endKw, err := p.consumeKeyword(lexer.TEnd)
+	if err != nil {
+		return nil, err
+	}
+	caseExpr.EndKeyword = *endKw
+	caseExpr.Span = ast.NewSpanFromLexerPosition(startPosition, endKw.EndPosition)
+
+	return &caseExpr, nil
+}
+
+func (p *Parser) parseWhenClause() (*ast.WhenClause, error) {
+	startPosition := p.peekToken.Start
+	whenKw, err := p.consumeKeyword(lexer.TWhen)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	thenKw, err := p.consumeKeyword(lexer.TThen)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.parseExpression(PrecedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.WhenClause{
+		WhenKeyword: *whenKw,
+		Condition:   condition,
+		ThenKeyword: *thenKw,
+		Result:      result,
+		Span:        ast.NewSpanFromLexerPosition(startPosition, result.GetSpan().EndPosition),
+	}, nil
+}
+
 func (p *Parser) parseCompoundIdentifier(expr ast.Expression) (*ast.ExprCompoundIdentifier, error) {
 	if p.peekToken.Type == lexer.TPeriod {
 		// we are dealing with a qualified identifier
@@ -1344,7 +4008,7 @@ func (p *Parser) parseCompoundIdentifier(expr ast.Expression) (*ast.ExprCompound
 				}
 				*compound = append(*compound, expr)
 			}
-			endPositionCompound = expr.GetSpan().EndPosition
+			endPositionCompound = (*compound)[len(*compound)-1].GetSpan().EndPosition
 			if token := p.maybeToken(lexer.TPeriod); token == nil {
 				break
 			}
@@ -1352,6 +4016,7 @@ func (p *Parser) parseCompoundIdentifier(expr ast.Expression) (*ast.ExprCompound
 
 		return &ast.ExprCompoundIdentifier{
 			Identifiers: *compound,
+			PseudoTable: pseudoTable(*compound),
 			Span:        ast.NewSpanFromLexerPosition(startPositionCompound, endPositionCompound),
 		}, nil
 	}
@@ -1359,6 +4024,25 @@ func (p *Parser) parseCompoundIdentifier(expr ast.Expression) (*ast.ExprCompound
 	return nil, nil
 }
 
+// `inserted.col` and `deleted.col` refer to the rows affected by a dml
+// statement or trigger rather than to a real table
+func pseudoTable(identifiers []ast.Expression) ast.PseudoTable {
+	if len(identifiers) != 2 {
+		return ast.PTNone
+	}
+	ident, ok := identifiers[0].(*ast.ExprIdentifier)
+	if !ok {
+		return ast.PTNone
+	}
+	switch {
+	case strings.EqualFold(ident.Value, "inserted"):
+		return ast.PTInserted
+	case strings.EqualFold(ident.Value, "deleted"):
+		return ast.PTDeleted
+	}
+	return ast.PTNone
+}
+
 func (p *Parser) parseFunction() (*ast.ExprFunction, error) {
 	var funcType ast.FuncType
 	switch p.peekToken.Type {
@@ -1444,6 +4128,10 @@ func (p *Parser) parseFunction() (*ast.ExprFunction, error) {
 		funcType = ast.FuncChecksum
 	case lexer.TNewId:
 		funcType = ast.FuncNewId
+	case lexer.TGrouping:
+		funcType = ast.FuncGrouping
+	case lexer.TGroupingId:
+		funcType = ast.FuncGroupingId
 	}
 	p.logger.Debug("in function parse")
 	function := &ast.ExprFunction{
@@ -1468,80 +4156,13 @@ func (p *Parser) parseFunctionArgs() (*[]ast.Expression, error) {
 			return nil, err
 		}
 
-		if token := p.maybeToken(lexer.TLocalVariable); token != nil {
-			args = append(args, &ast.ExprLocalVariable{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			})
-		} else if token := p.maybeToken(lexer.TQuotedIdentifier); token != nil {
-			args = append(args, &ast.ExprQuotedIdentifier{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			})
-		} else if token := p.maybeToken(lexer.TStringLiteral); token != nil {
-			args = append(args, &ast.ExprStringLiteral{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			})
-		} else if token := p.maybeToken(lexer.TNumericLiteral); token != nil {
-			args = append(args, &ast.ExprNumberLiteral{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			})
-		} else if token := p.maybeToken(lexer.TIdentifier); token != nil {
-			// check if we have a compound identifier
-			identifier := &ast.ExprIdentifier{
-				Value: token.Value,
-				Span:  ast.NewSpanFromToken(*token),
-			}
-			compoundIdentifier, err := p.parseCompoundIdentifier(identifier)
-			if err != nil {
-				return nil, err
-			}
-
-			// we have a compoundIdentifier
-			if compoundIdentifier != nil {
-				endCompoundIdentifierPosition := compoundIdentifier.EndPosition
-				if p.peekTokenIs(lexer.TLeftParen) {
-					function := &ast.ExprFunction{
-						Type: ast.FuncUserDefined,
-						Name: compoundIdentifier,
-						Span: ast.NewSpanFromLexerPosition(identifier.StartPosition, endCompoundIdentifierPosition),
-					}
-					functionCall, err := p.parseFunctionCall(function)
-					if err != nil {
-						return nil, err
-					}
-
-					args = append(args, functionCall)
-				} else {
-					args = append(args, compoundIdentifier)
-				}
-			} else if p.peekTokenIs(lexer.TLeftParen) {
-				function := &ast.ExprFunction{
-					Type: ast.FuncUserDefined,
-					Name: identifier,
-					Span: ast.NewSpanFromLexerPosition(identifier.StartPosition, identifier.EndPosition),
-				}
-
-				functionCall, err := p.parseFunctionCall(function)
-				if err != nil {
-					return nil, err
-				}
-
-				args = append(args, functionCall)
-			} else {
-				args = append(args, identifier)
-			}
-		} else {
-			functionCall, err := p.parseFunctionCall(nil)
-			if err != nil {
-				return nil, err
-			}
-			args = append(args, functionCall)
+		expr, err := p.parseExpression(PrecedenceLowest)
+		if err != nil {
+			return nil, err
 		}
+		args = append(args, expr)
 
-		if token := p.maybeToken(lexer.TRightParen); token == nil {
+		if token := p.maybeToken(lexer.TComma); token == nil {
 			break
 		}
 	}
@@ -1565,12 +4186,22 @@ func (p *Parser) parseFunctionCall(function *ast.ExprFunction) (*ast.ExprFunctio
 		return nil, err
 	}
 	args := []ast.Expression{}
+	if takesDatePart(function) {
+		datePart, err := p.parseDatePart()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, datePart)
+		if _, err := p.consumeToken(lexer.TComma); err != nil {
+			return nil, err
+		}
+	}
 	if !p.peekTokenIs(lexer.TRightParen) {
 		functionArgs, err := p.parseFunctionArgs()
 		if err != nil {
 			return nil, err
 		}
-		args = *functionArgs
+		args = append(args, *functionArgs...)
 	}
 
 	rightParen, err := p.consumeToken(lexer.TRightParen)
@@ -1578,16 +4209,26 @@ func (p *Parser) parseFunctionCall(function *ast.ExprFunction) (*ast.ExprFunctio
 		p.logger.Debug("right parenthesis, got ", p.peekToken.Value)
 		return nil, err
 	}
+	functionCall := &ast.ExprFunctionCall{
+		Name: function,
+		Args: args,
+		Span: ast.NewSpanFromLexerPosition(function.StartPosition, rightParen.End),
+	}
+
+	// within is not a keyword so it has to be followed by GROUP
+	if p.peekTokenIs(lexer.TIdentifier) && strings.EqualFold(p.peekToken.Value, "within") &&
+		p.peekSecondTokenIs(lexer.TGroup) {
+		withinGroup, err := p.parseWithinGroupClause()
+		if err != nil {
+			return nil, err
+		}
+		functionCall.WithinGroupClause = withinGroup
+		functionCall.Span = ast.NewSpanFromLexerPosition(function.StartPosition, withinGroup.EndPosition)
+	}
+
 	// check for over clause
 	if !p.peekTokenIs(lexer.TOver) {
-		return &ast.ExprFunctionCall{
-			Name: function,
-			Args: args,
-			Span: ast.Span{
-				StartPosition: function.StartPosition,
-				EndPosition:   rightParen.End,
-			},
-		}, nil
+		return functionCall, nil
 	}
 
 	overClause, err := p.parseOverClause()
@@ -1597,14 +4238,191 @@ func (p *Parser) parseFunctionCall(function *ast.ExprFunction) (*ast.ExprFunctio
 
 	p.logger.Debug(overClause.TokenLiteral())
 
-	return &ast.ExprFunctionCall{
-		Name:       function,
-		Args:       args,
-		OverClause: overClause,
-		Span: ast.Span{
-			StartPosition: function.StartPosition,
-			EndPosition:   overClause.EndPosition,
-		},
+	functionCall.OverClause = overClause
+	functionCall.Span = ast.NewSpanFromLexerPosition(function.StartPosition, overClause.EndPosition)
+
+	return functionCall, nil
+}
+
+// system functions that are not keywords, their names are printed like the
+// builtin functions
+var system_functions = []string{
+	"charindex",
+	"choose",
+	"coalesce",
+	"concat",
+	"concat_ws",
+	"count_big",
+	"datalength",
+	"dateadd",
+	"datediff",
+	"datediff_big",
+	"datefromparts",
+	"datename",
+	"datepart",
+	"datetrunc",
+	"eomonth",
+	"error_line",
+	"error_message",
+	"error_number",
+	"error_procedure",
+	"error_severity",
+	"error_state",
+	"format",
+	"getutcdate",
+	"iif",
+	"isdate",
+	"isjson",
+	"isnull",
+	"isnumeric",
+	"json_modify",
+	"json_query",
+	"json_value",
+	"len",
+	"lower",
+	"ltrim",
+	"ntile",
+	"object_id",
+	"patindex",
+	"percentile_cont",
+	"percentile_disc",
+	"quotename",
+	"replace",
+	"replicate",
+	"reverse",
+	"rtrim",
+	"scope_identity",
+	"string_agg",
+	"string_split",
+	"stuff",
+	"substring",
+	"sysdatetime",
+	"trim",
+	"xact_state",
+}
+
+// builds the function called through the user defined path, system functions
+// get a builtin function name
+func newFunction(name ast.Expression) *ast.ExprFunction {
+	function := &ast.ExprFunction{
+		Type: ast.FuncUserDefined,
+		Name: name,
+		Span: name.GetSpan(),
+	}
+	identifier, ok := name.(*ast.ExprIdentifier)
+	if !ok {
+		return function
+	}
+	for _, f := range system_functions {
+		if strings.EqualFold(identifier.Value, f) {
+			function.Type = ast.FuncSystem
+			function.Name = &ast.ExprBuiltInFunctionName{Value: identifier.Value, Span: identifier.Span}
+			break
+		}
+	}
+	return function
+}
+
+// system functions that are lexed as keywords
+var keyword_functions = []lexer.TokenType{
+	lexer.TChar,
+	lexer.TDay,
+	lexer.TLeft,
+	lexer.TMonth,
+	lexer.TNullif,
+	lexer.TRight,
+	lexer.TUpper,
+	lexer.TYear,
+}
+
+var date_part_functions = []string{
+	"dateadd",
+	"datediff",
+	"datediff_big",
+	"datename",
+	"datepart",
+	"datetrunc",
+}
+
+func takesDatePart(function *ast.ExprFunction) bool {
+	if function.Type != ast.FuncSystem {
+		return false
+	}
+	name, ok := function.Name.(*ast.ExprBuiltInFunctionName)
+	if !ok {
+		return false
+	}
+	for _, f := range date_part_functions {
+		if strings.EqualFold(name.Value, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// some date parts like day and year are keywords, the rest are plain identifiers
+var date_part_keywords = []lexer.TokenType{
+	lexer.TYear,
+	lexer.TMonth,
+	lexer.TWeek,
+	lexer.TDay,
+	lexer.TDayofyear,
+	lexer.THour,
+	lexer.TMinute,
+	lexer.TSecond,
+	lexer.TMillisecond,
+	lexer.TMicrosecond,
+	lexer.TNanosecond,
+}
+
+func (p *Parser) parseDatePart() (*ast.ExprDatePart, error) {
+	if !p.peekTokenIs(lexer.TIdentifier) && !p.peekTokenIsAny(date_part_keywords) {
+		return nil, p.peekErrorString("date part")
+	}
+	datePart := &ast.ExprDatePart{
+		Value: p.peekToken.Value,
+		Span:  ast.NewSpanFromToken(p.peekToken),
+	}
+	p.nextToken()
+
+	return datePart, nil
+}
+
+func (p *Parser) parseWithinGroupClause() (*ast.WithinGroupClause, error) {
+	startPosition := p.peekToken.Start
+	withinKw := p.maybeIdentifierKeyword("within")
+	if withinKw == nil {
+		return nil, p.peekErrorString("Within")
+	}
+	groupKw, err := p.consumeKeyword(lexer.TGroup)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.consumeToken(lexer.TLeftParen); err != nil {
+		return nil, err
+	}
+	orderKw, err := p.consumeKeyword(lexer.TOrder)
+	if err != nil {
+		return nil, err
+	}
+	byKw, err := p.consumeKeyword(lexer.TBy)
+	if err != nil {
+		return nil, err
+	}
+	args, err := p.parseOrderByArgs()
+	if err != nil {
+		return nil, err
+	}
+	rightParen, err := p.consumeToken(lexer.TRightParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.WithinGroupClause{
+		WithinGroupKeyword: [2]ast.Keyword{*withinKw, *groupKw},
+		OrderByKeyword:     [2]ast.Keyword{*orderKw, *byKw},
+		OrderByClause:      args,
+		Span:               ast.NewSpanFromLexerPosition(startPosition, rightParen.End),
 	}, nil
 }
 
@@ -1701,6 +4519,7 @@ func (p *Parser) parseInLogicalOperator(left ast.Expression, notKw *ast.Keyword)
 		lexer.TLocalVariable,
 		lexer.TQuotedIdentifier,
 		lexer.TStringLiteral,
+		lexer.TNationalStringLiteral,
 		lexer.TNumericLiteral,
 	}) {
 		inExpressionList, err := p.parseInExpressionListLogicalOperator(left, *inKw, notKw)